@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Accumulator atomically combines values of type T into a single result using a combine function with an identity
+// value, such as a sum with 0, a maximum, or string concatenation with "". The Accumulator holds a pointer to its
+// current result, so that T need not be comparable. Accumulators must be created using NewAccumulator.
+type Accumulator[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	identity T
+	combine  func(a, b T) T
+	v        Value[*T]
+}
+
+// NewAccumulator creates a new Accumulator holding identity. combine is called with the current result and the value
+// accumulated, and must return a new result without modifying either. combine may be called more than once for a
+// single call to Accumulate if the Accumulator is modified concurrently.
+func NewAccumulator[T any](identity T, combine func(a, b T) T) *Accumulator[T] {
+	return &Accumulator[T]{identity: identity, combine: combine}
+}
+
+// Accumulate atomically replaces the current result with combine(current, val) and returns the new result.
+func (a *Accumulator[T]) Accumulate(val T) T {
+	new, _ := a.v.update(func(old *T) (*T, bool) {
+		new := a.combine(a.load(old), val)
+		return &new, true
+	})
+	return *new
+}
+
+// Load atomically loads the current result.
+func (a *Accumulator[T]) Load() T {
+	return a.load(a.v.Load())
+}
+
+// Reset atomically resets the current result to the identity value.
+func (a *Accumulator[T]) Reset() {
+	a.v.Store(nil)
+}
+
+// load returns the result that p points to, or the identity value if p is nil.
+func (a *Accumulator[T]) load(p *T) T {
+	if p == nil {
+		return a.identity
+	}
+	return *p
+}
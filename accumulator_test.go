@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccumulator(t *testing.T) {
+	max := NewAccumulator(0, func(a, b int) int {
+		if b > a {
+			return b
+		}
+		return a
+	})
+	assert.Equal(t, 0, max.Load(), "new Accumulator didn't hold the identity value.")
+	assert.Equal(t, 3, max.Accumulate(3), "Accumulate didn't return the new result.")
+	assert.Equal(t, 3, max.Accumulate(1), "Accumulate didn't combine the values.")
+	assert.Equal(t, 3, max.Load(), "Load didn't return the current result.")
+
+	max.Reset()
+	assert.Equal(t, 0, max.Load(), "Reset didn't restore the identity value.")
+}
+
+func TestAccumulatorConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		values     = 100
+	)
+
+	var (
+		sum    = NewAccumulator(0, func(a, b int) int { return a + b })
+		concat = NewAccumulator("", func(a, b string) string { return a + b })
+		wg     sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		s := string(rune('a' + i))
+		go func() {
+			defer wg.Done()
+			for j := 0; j < values; j++ {
+				sum.Accumulate(1)
+				concat.Accumulate(s)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*values, sum.Load(), "concurrent sum lost values.")
+	chars := strings.Split(concat.Load(), "")
+	sort.Strings(chars)
+	var want []string
+	for i := 0; i < goroutines; i++ {
+		for j := 0; j < values; j++ {
+			want = append(want, string(rune('a'+i)))
+		}
+	}
+	assert.Equal(t, want, chars, "concurrent concatenation lost values.")
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// AtomicMap is a map of Values of type V keyed by K. Every key has its own Value, which may be loaded and modified
+// atomically without affecting other keys. AtomicMap is backed by a sync.Map and is safe for concurrent use. The zero
+// value is an empty map ready to use.
+type AtomicMap[K comparable, V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	m sync.Map
+}
+
+// LoadOrCreate returns the Value for key, creating an unset Value if key is not yet present. Concurrent calls to
+// LoadOrCreate for the same key always return the same Value.
+func (m *AtomicMap[K, V]) LoadOrCreate(key K) *Value[V] {
+	if v, ok := m.m.Load(key); ok {
+		return v.(*Value[V])
+	}
+	v, _ := m.m.LoadOrStore(key, &Value[V]{})
+	return v.(*Value[V])
+}
+
+// Load returns the Value for key, or nil and false if key is not present.
+func (m *AtomicMap[K, V]) Load(key K) (v *Value[V], ok bool) {
+	raw, ok := m.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return raw.(*Value[V]), true
+}
+
+// Range calls f with every key present and the value currently held by its Value. Range stops if f returns false.
+// Like sync.Map.Range, Range does not correspond to a consistent snapshot of the AtomicMap, and every Value is loaded
+// independently.
+func (m *AtomicMap[K, V]) Range(f func(key K, val V) bool) {
+	m.m.Range(func(key, v any) bool {
+		return f(key.(K), v.(*Value[V]).Load())
+	})
+}
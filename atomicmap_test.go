@@ -0,0 +1,94 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicMap(t *testing.T) {
+	var m AtomicMap[string, int]
+	_, ok := m.Load("foo")
+	assert.False(t, ok, "Load of a missing key returned true.")
+
+	v := m.LoadOrCreate("foo")
+	assert.False(t, v.IsSet(), "LoadOrCreate created a set Value.")
+	v.Store(1)
+	m.LoadOrCreate("bar").Store(2)
+
+	loaded, ok := m.Load("foo")
+	require.True(t, ok, "Load of a present key returned false.")
+	assert.True(t, loaded == v, "Load didn't return the Value created.")
+	assert.True(t, m.LoadOrCreate("foo") == v, "LoadOrCreate didn't return the existing Value.")
+
+	vals := map[string]int{}
+	m.Range(func(key string, val int) bool {
+		vals[key] = val
+		return true
+	})
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, vals, "Range didn't visit every key.")
+
+	n := 0
+	m.Range(func(string, int) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n, "Range didn't stop when f returned false.")
+}
+
+func TestAtomicMapConcurrent(t *testing.T) {
+	const goroutines = 8
+
+	var (
+		m       AtomicMap[int, int64]
+		wg      sync.WaitGroup
+		created [goroutines]*Value[int64]
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			created[i] = m.LoadOrCreate(1)
+			for j := 0; j < 100; j++ {
+				m.LoadOrCreate(i + 2).Store(int64(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range created {
+		assert.True(t, created[i] == created[0], "concurrent LoadOrCreate returned different Values for a key.")
+	}
+	n := 0
+	m.Range(func(key int, val int64) bool {
+		n++
+		if key != 1 {
+			assert.Equal(t, int64(99), val, "Value of key %v doesn't hold the last value stored.", key)
+		}
+		return true
+	})
+	assert.Equal(t, goroutines+1, n, "Range didn't visit every key.")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AuditedValue is a value of type T that records every store in an audit log, along with who stored the value and
+// when. The audit log is a ring that holds the most recent stores only, the number of which is fixed at
+// construction. Loading the value is lock-free, while stores are serialised so that the audit log reflects the exact
+// order of stores. AuditedValues must be created using NewAuditedValue.
+type AuditedValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v   Value[T]
+	now func() time.Time
+
+	mu   sync.Mutex
+	ring []AuditEntry[T]
+	next int
+	full bool
+}
+
+// AuditEntry is an entry in the audit log of an AuditedValue, recording a single store.
+type AuditEntry[T any] struct {
+	// Time is the time at which the value was stored.
+	Time time.Time
+	// Actor is the actor passed to AuditedValue.Store.
+	Actor string
+	// Old is the value held before the store, or the zero value of T if the AuditedValue was unset.
+	Old T
+	// New is the value stored.
+	New T
+}
+
+// NewAuditedValue creates a new, unset AuditedValue whose audit log holds the size most recent stores. size must be
+// at least 1.
+func NewAuditedValue[T any](size int) *AuditedValue[T] {
+	if size < 1 {
+		panic(fmt.Sprintf("atomic: NewAuditedValue called with size %v less than 1", size))
+	}
+	return &AuditedValue[T]{now: time.Now, ring: make([]AuditEntry[T], size)}
+}
+
+// Load atomically loads the value held, or the zero value of T if no value was stored yet.
+func (a *AuditedValue[T]) Load() T {
+	return a.v.Load()
+}
+
+// Store atomically stores val and records the store by actor in the audit log, replacing the oldest entry if the
+// audit log is full.
+func (a *AuditedValue[T]) Store(val T, actor string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	old := a.v.Swap(val)
+	a.ring[a.next] = AuditEntry[T]{Time: a.now(), Actor: actor, Old: old, New: val}
+	a.next = (a.next + 1) % len(a.ring)
+	if a.next == 0 {
+		a.full = true
+	}
+}
+
+// AuditLog returns a snapshot of the audit log, ordered from the oldest to the most recent store.
+func (a *AuditedValue[T]) AuditLog() []AuditEntry[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.full {
+		return append([]AuditEntry[T](nil), a.ring[:a.next]...)
+	}
+	log := make([]AuditEntry[T], 0, len(a.ring))
+	log = append(log, a.ring[a.next:]...)
+	return append(log, a.ring[:a.next]...)
+}
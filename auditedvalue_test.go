@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditedValue(t *testing.T) {
+	a := NewAuditedValue[int](3)
+	base := time.Unix(0, 0)
+	var tick time.Duration
+	a.now = func() time.Time {
+		tick += time.Second
+		return base.Add(tick)
+	}
+	assert.Empty(t, a.AuditLog(), "audit log of a new AuditedValue wasn't empty.")
+
+	a.Store(1, "alice")
+	a.Store(2, "bob")
+	assert.Equal(t, 2, a.Load(), "Load didn't return the value stored.")
+	assert.Equal(t, []AuditEntry[int]{
+		{Time: base.Add(time.Second), Actor: "alice", Old: 0, New: 1},
+		{Time: base.Add(2 * time.Second), Actor: "bob", Old: 1, New: 2},
+	}, a.AuditLog(), "audit log didn't match the stores.")
+
+	a.Store(3, "carol")
+	a.Store(4, "dave")
+	log := a.AuditLog()
+	assert.Equal(t, []AuditEntry[int]{
+		{Time: base.Add(2 * time.Second), Actor: "bob", Old: 1, New: 2},
+		{Time: base.Add(3 * time.Second), Actor: "carol", Old: 2, New: 3},
+		{Time: base.Add(4 * time.Second), Actor: "dave", Old: 3, New: 4},
+	}, log, "full audit log didn't drop the oldest entry.")
+
+	a.Store(5, "erin")
+	assert.Equal(t, "bob", log[0].Actor, "Store modified a snapshot returned by AuditLog.")
+	assert.Panics(t, func() { NewAuditedValue[int](0) }, "NewAuditedValue with size 0 didn't panic.")
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "math/big"
+
+// BigInt is an atomic wrapper around an arbitrary-precision integer, for counters that may exceed the range of an
+// int64. Because big.Int is mutable, BigInt never exposes the big.Int it holds: every write publishes a new big.Int
+// that is not modified afterwards, and Load returns a copy. The zero value holds 0.
+type BigInt struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*big.Int]
+}
+
+// NewBigInt creates a new BigInt holding a copy of val.
+func NewBigInt(val *big.Int) *BigInt {
+	b := &BigInt{}
+	b.Store(val)
+	return b
+}
+
+// Load atomically loads the wrapped integer and returns a copy of it, which the caller may modify freely.
+func (b *BigInt) Load() *big.Int {
+	return copyBigInt(b.v.Load())
+}
+
+// Store atomically stores a copy of val. Modifying val after the call does not affect the BigInt.
+func (b *BigInt) Store(val *big.Int) {
+	b.v.Store(copyBigInt(val))
+}
+
+// Add atomically adds delta to the wrapped integer and returns a copy of the new value.
+func (b *BigInt) Add(delta *big.Int) *big.Int {
+	new, _ := b.v.update(func(old *big.Int) (*big.Int, bool) {
+		return new(big.Int).Add(orZeroBigInt(old), delta), true
+	})
+	return copyBigInt(new)
+}
+
+// CompareAndSwap atomically stores a copy of new if the wrapped integer is numerically equal to old, as reported by
+// big.Int.Cmp, and reports whether it did so.
+func (b *BigInt) CompareAndSwap(old, new *big.Int) (swapped bool) {
+	_, swapped = b.v.update(func(current *big.Int) (*big.Int, bool) {
+		if orZeroBigInt(current).Cmp(old) != 0 {
+			return nil, false
+		}
+		return copyBigInt(new), true
+	})
+	return swapped
+}
+
+// String returns the wrapped integer in decimal.
+func (b *BigInt) String() string {
+	return orZeroBigInt(b.v.Load()).String()
+}
+
+// orZeroBigInt returns x, or a big.Int holding 0 if x is nil.
+func orZeroBigInt(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
+// copyBigInt returns a new big.Int holding the value of x, or 0 if x is nil.
+func copyBigInt(x *big.Int) *big.Int {
+	return new(big.Int).Set(orZeroBigInt(x))
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigInt(t *testing.T) {
+	var b BigInt
+	assert.Equal(t, "0", b.String(), "zero BigInt didn't hold 0.")
+	assert.Equal(t, int64(0), b.Load().Int64(), "Load of a zero BigInt didn't return 0.")
+
+	max := new(big.Int).Lsh(big.NewInt(1), 100)
+	b.Store(max)
+	max.SetInt64(1)
+	assert.Equal(t, "1267650600228229401496703205376", b.String(), "Store didn't copy the value.")
+
+	loaded := b.Load()
+	loaded.SetInt64(2)
+	assert.Equal(t, "1267650600228229401496703205376", b.String(), "Load didn't return a copy.")
+
+	assert.Equal(t, "1267650600228229401496703205377", b.Add(big.NewInt(1)).String(),
+		"Add didn't return the new value.")
+
+	assert.False(t, b.CompareAndSwap(big.NewInt(1), big.NewInt(2)), "CompareAndSwap swapped a mismatching value.")
+	n, _ := new(big.Int).SetString("1267650600228229401496703205377", 10)
+	assert.True(t, b.CompareAndSwap(n, big.NewInt(3)), "CompareAndSwap didn't swap an equal value.")
+	assert.Equal(t, "3", b.String(), "CompareAndSwap didn't store the new value.")
+
+	var zero BigInt
+	assert.True(t, zero.CompareAndSwap(big.NewInt(0), big.NewInt(4)), "CompareAndSwap of a zero BigInt didn't swap 0.")
+	assert.Equal(t, "4", NewBigInt(zero.Load()).String(), "NewBigInt didn't hold the value passed.")
+}
+
+func TestBigIntConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		adds       = 500
+	)
+
+	var (
+		b     BigInt
+		wg    sync.WaitGroup
+		delta = new(big.Int).Lsh(big.NewInt(1), 64)
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < adds; j++ {
+				b.Add(delta)
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := new(big.Int).Mul(delta, big.NewInt(goroutines*adds))
+	assert.Equal(t, 0, want.Cmp(b.Load()), "concurrent Add lost additions: got %v, want %v", b.Load(), want)
+}
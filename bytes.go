@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Bytes is a byte buffer that may be appended to concurrently, while readers load snapshots of it lock-free. Every
+// Append copies the buffer, so that snapshots are never modified after they were loaded. This makes Bytes suited for
+// small logs that are read more often than they are written to, as appending to a buffer of n bytes costs O(n). The
+// zero value is an empty buffer.
+type Bytes struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*[]byte]
+}
+
+// Append atomically appends p to the buffer and returns the new length of the buffer. Concurrent calls to Append
+// are serialised by the compare-and-swap loop publishing the new buffer, so every p is appended in full, without
+// interleaving with others.
+func (b *Bytes) Append(p []byte) int {
+	new, _ := b.v.update(func(old *[]byte) (*[]byte, bool) {
+		var buf []byte
+		if old != nil {
+			buf = make([]byte, len(*old), len(*old)+len(p))
+			copy(buf, *old)
+		}
+		buf = append(buf, p...)
+		return &buf, true
+	})
+	return len(*new)
+}
+
+// Load atomically loads a snapshot of the buffer. The slice returned is shared with other readers and must not be
+// modified. Load returns nil if nothing was appended yet.
+func (b *Bytes) Load() []byte {
+	if p := b.v.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Len returns the length of the buffer in O(1). The length is taken from the same snapshot Load would return, so it
+// is always consistent with a Load.
+func (b *Bytes) Len() int {
+	return len(b.Load())
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	var b Bytes
+	assert.Nil(t, b.Load(), "Load of an empty Bytes didn't return nil.")
+	assert.Equal(t, 0, b.Len(), "Len of an empty Bytes wasn't 0.")
+
+	p := []byte("foo")
+	assert.Equal(t, 3, b.Append(p), "Append didn't return the new length.")
+	p[0] = 'x'
+	snapshot := b.Load()
+	assert.Equal(t, 6, b.Append([]byte("bar")), "Append didn't return the new length.")
+
+	assert.Equal(t, []byte("foo"), snapshot, "Append modified a snapshot loaded before.")
+	assert.Equal(t, []byte("foobar"), b.Load(), "Load didn't return all bytes appended.")
+	assert.Equal(t, 6, b.Len(), "Len didn't return the length of the buffer.")
+}
+
+func TestBytesConcurrentAppend(t *testing.T) {
+	const (
+		goroutines = 8
+		appends    = 100
+	)
+
+	var (
+		b  Bytes
+		wg sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		chunk := bytes.Repeat([]byte{byte('a' + i)}, 4)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < appends; j++ {
+				b.Append(chunk)
+				if n := b.Len(); n%4 != 0 {
+					assert.Fail(t, "Len observed a partial append.", "length %v", n)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	buf := b.Load()
+	assert.Equal(t, goroutines*appends*4, b.Len(), "concurrent Append lost bytes.")
+	for i := 0; i < len(buf); i += 4 {
+		assert.Equal(t, bytes.Repeat(buf[i:i+1], 4), buf[i:i+4], "appends were interleaved at offset %v.", i)
+	}
+}
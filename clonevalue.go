@@ -0,0 +1,57 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Cloneable is implemented by types that can produce an independent copy of themselves.
+type Cloneable[T any] interface {
+	// Clone returns a copy of the value that shares no mutable state with it.
+	Clone() T
+}
+
+// CloneValue is a Value holding a Cloneable type T whose Load always returns a clone of the value held, so that
+// callers cannot modify the value shared with other readers. The zero value holds the zero value of T, which is
+// returned by Load without calling Clone.
+type CloneValue[T Cloneable[T]] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[T]
+}
+
+// NewCloneValue creates a new CloneValue holding val.
+func NewCloneValue[T Cloneable[T]](val T) *CloneValue[T] {
+	c := &CloneValue[T]{}
+	c.Store(val)
+	return c
+}
+
+// Load atomically loads the value held and returns a clone of it.
+func (c *CloneValue[T]) Load() T {
+	val, ok := c.v.Value.Load().(wrapper[T])
+	if !ok {
+		return val.val
+	}
+	return val.val.Clone()
+}
+
+// Store atomically stores val. val is stored as is, so the caller must not modify it after the call.
+func (c *CloneValue[T]) Store(val T) {
+	c.v.Store(val)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cloneableSlice []int
+
+func (s cloneableSlice) Clone() cloneableSlice {
+	return append(cloneableSlice(nil), s...)
+}
+
+func TestCloneValue(t *testing.T) {
+	var zero CloneValue[cloneableSlice]
+	assert.Nil(t, zero.Load(), "Load of a zero CloneValue didn't return the zero value.")
+
+	c := NewCloneValue(cloneableSlice{1, 2})
+	loaded := c.Load()
+	loaded[0] = 3
+	assert.Equal(t, cloneableSlice{1, 2}, c.Load(), "Load didn't return a clone.")
+
+	c.Store(cloneableSlice{4})
+	assert.Equal(t, cloneableSlice{4}, c.Load(), "Store didn't store the value.")
+}
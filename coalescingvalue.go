@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"time"
+)
+
+// CoalescingValue is a value of type T whose writes are applied to a sink by a background goroutine at most once per
+// interval. Store only updates the value in memory, and at the end of every interval in which the value was stored
+// to, the latest value is passed to the sink. Intermediate values stored within the same interval are dropped
+// intentionally, which smooths bursts of writes into a downstream with limited throughput. CoalescingValues must be
+// created using NewCoalescingValue, and stopped using Close once no longer used.
+type CoalescingValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v     Value[T]
+	dirty Bool
+	sink  func(T)
+
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewCoalescingValue creates a new, unset CoalescingValue and starts the goroutine that passes the latest value stored
+// to sink at most every interval. sink is only ever called from a single goroutine at a time.
+func NewCoalescingValue[T any](interval time.Duration, sink func(T)) *CoalescingValue[T] {
+	c := &CoalescingValue[T]{sink: sink, stop: make(chan struct{}), done: make(chan struct{})}
+	go c.run(interval)
+	return c
+}
+
+// run applies the latest value stored to the sink every interval until the CoalescingValue is closed.
+func (c *CoalescingValue[T]) run(interval time.Duration) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.flush()
+		case <-c.stop:
+			c.flush()
+			return
+		}
+	}
+}
+
+// flush passes the latest value stored to the sink if it was stored to since the last flush.
+func (c *CoalescingValue[T]) flush() {
+	if c.dirty.Swap(false) {
+		c.sink(c.v.Load())
+	}
+}
+
+// Store atomically stores val, which is passed to the sink at the end of the current interval unless another value
+// is stored before.
+func (c *CoalescingValue[T]) Store(val T) {
+	c.v.Store(val)
+	c.dirty.Store(true)
+}
+
+// Load atomically loads the latest value stored, which may not have been passed to the sink yet.
+func (c *CoalescingValue[T]) Load() T {
+	return c.v.Load()
+}
+
+// Close passes the latest value to the sink if it was not yet applied, and stops the background goroutine. Close
+// blocks until the goroutine has exited. Values stored after Close are never passed to the sink. Calling Close more
+// than once has no effect.
+func (c *CoalescingValue[T]) Close() {
+	c.once.Do(func() { close(c.stop) })
+	<-c.done
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalescingValue(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	var (
+		mu      sync.Mutex
+		applied []int
+	)
+	c := NewCoalescingValue(time.Hour, func(val int) {
+		mu.Lock()
+		defer mu.Unlock()
+		applied = append(applied, val)
+	})
+	for i := 1; i <= 100; i++ {
+		c.Store(i)
+	}
+	assert.Equal(t, 100, c.Load(), "Load didn't return the latest value stored.")
+
+	c.Close()
+	c.Close()
+	assert.Equal(t, []int{100}, applied, "Close didn't apply only the latest value.")
+
+	c.Store(101)
+	assert.Equal(t, []int{100}, applied, "Store after Close applied a value.")
+	assert.True(t, runtime.NumGoroutine() <= goroutines, "Close didn't stop the background goroutine.")
+}
+
+func TestCoalescingValueInterval(t *testing.T) {
+	applied := make(chan int, 10)
+	c := NewCoalescingValue(5*time.Millisecond, func(val int) { applied <- val })
+	defer c.Close()
+
+	c.Store(1)
+	c.Store(2)
+	select {
+	case val := <-applied:
+		assert.Equal(t, 2, val, "interval didn't apply the latest value.")
+	case <-time.After(time.Second):
+		t.Fatal("value wasn't applied within the interval.")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, applied, "interval without stores applied a value.")
+
+	c.Store(3)
+	select {
+	case val := <-applied:
+		assert.Equal(t, 3, val, "interval didn't apply the latest value.")
+	case <-time.After(time.Second):
+		t.Fatal("value wasn't applied within the interval.")
+	}
+}
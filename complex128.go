@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"strconv"
+)
+
+// Complex128 is an atomic type-safe wrapper for complex128 values. As a complex128 does not fit in a single atomic
+// word, Complex128 holds a pointer to an immutable complex128, and every write publishes a new one.
+type Complex128 struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*complex128]
+}
+
+// NewComplex128 creates a new Complex128.
+func NewComplex128(val complex128) *Complex128 {
+	x := &Complex128{}
+	x.Store(val)
+	return x
+}
+
+// Load atomically loads the wrapped complex128.
+func (x *Complex128) Load() complex128 {
+	if p := x.v.Load(); p != nil {
+		return *p
+	}
+	return 0
+}
+
+// Store atomically stores the passed complex128.
+func (x *Complex128) Store(val complex128) {
+	x.v.Store(&val)
+}
+
+// CAS is an atomic compare-and-swap for complex128 values. Like the CAS of Complex64, CAS compares the bits of the
+// values, so that a stored NaN compares equal to an identical NaN passed, and 0 and -0 unequal.
+func (x *Complex128) CAS(old, new complex128) (swapped bool) {
+	_, swapped = x.v.update(func(p *complex128) (*complex128, bool) {
+		var current complex128
+		if p != nil {
+			current = *p
+		}
+		if !equalComplex128Bits(current, old) {
+			return nil, false
+		}
+		return &new, true
+	})
+	return swapped
+}
+
+// Swap atomically stores the given complex128 and returns the old
+// value.
+func (x *Complex128) Swap(val complex128) (old complex128) {
+	if p := x.v.Swap(&val); p != nil {
+		return *p
+	}
+	return 0
+}
+
+// Add atomically adds to the wrapped complex128 and returns the new value.
+func (x *Complex128) Add(delta complex128) complex128 {
+	new, _ := x.v.update(func(p *complex128) (*complex128, bool) {
+		new := delta
+		if p != nil {
+			new += *p
+		}
+		return &new, true
+	})
+	return *new
+}
+
+// String encodes the wrapped value as a string.
+func (x *Complex128) String() string {
+	return strconv.FormatComplex(x.Load(), 'g', -1, 128)
+}
+
+// equalComplex128Bits checks if the real and imaginary parts of a and b have identical bits.
+func equalComplex128Bits(a, b complex128) bool {
+	return math.Float64bits(real(a)) == math.Float64bits(real(b)) &&
+		math.Float64bits(imag(a)) == math.Float64bits(imag(b))
+}
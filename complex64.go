@@ -0,0 +1,62 @@
+// @generated Code generated by gen-atomicwrapper.
+
+// Copyright (c) 2020-2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Complex64 is an atomic type-safe wrapper for complex64 values.
+type Complex64 struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Uint64
+}
+
+var _zeroComplex64 complex64
+
+// NewComplex64 creates a new Complex64.
+func NewComplex64(val complex64) *Complex64 {
+	x := &Complex64{}
+	if val != _zeroComplex64 {
+		x.Store(val)
+	}
+	return x
+}
+
+// Load atomically loads the wrapped complex64.
+func (x *Complex64) Load() complex64 {
+	return uint64ToComplex64(x.v.Load())
+}
+
+// Store atomically stores the passed complex64.
+func (x *Complex64) Store(val complex64) {
+	x.v.Store(complex64ToUint64(val))
+}
+
+// CAS is an atomic compare-and-swap for complex64 values.
+func (x *Complex64) CAS(old, new complex64) (swapped bool) {
+	return x.v.CAS(complex64ToUint64(old), complex64ToUint64(new))
+}
+
+// Swap atomically stores the given complex64 and returns the old
+// value.
+func (x *Complex64) Swap(val complex64) (old complex64) {
+	return uint64ToComplex64(x.v.Swap(complex64ToUint64(val)))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"strconv"
+)
+
+//go:generate bin/gen-atomicwrapper -name=Complex64 -type=complex64 -wrapped=Uint64 -pack=complex64ToUint64 -unpack=uint64ToComplex64 -cas -swap -file=complex64.go
+
+// complex64ToUint64 packs the real and imaginary parts of c into the upper and lower 32 bits of a uint64. As the
+// bits are compared, CAS considers a stored NaN equal to an identical NaN passed, and 0 and -0 unequal.
+func complex64ToUint64(c complex64) uint64 {
+	return uint64(math.Float32bits(real(c)))<<32 | uint64(math.Float32bits(imag(c)))
+}
+
+// uint64ToComplex64 unpacks a complex64 packed by complex64ToUint64.
+func uint64ToComplex64(v uint64) complex64 {
+	return complex(math.Float32frombits(uint32(v>>32)), math.Float32frombits(uint32(v)))
+}
+
+// Add atomically adds to the wrapped complex64 and returns the new value.
+func (c *Complex64) Add(delta complex64) complex64 {
+	for {
+		old := c.Load()
+		new := old + delta
+		if c.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// String encodes the wrapped value as a string.
+func (c *Complex64) String() string {
+	return strconv.FormatComplex(complex128(c.Load()), 'g', -1, 64)
+}
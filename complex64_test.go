@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplex64(t *testing.T) {
+	atom := NewComplex64(1 + 2i)
+
+	require.Equal(t, complex64(1+2i), atom.Load(), "Load didn't work.")
+	require.Equal(t, complex64(2+4i), atom.Add(1+2i), "Add didn't work.")
+
+	require.True(t, atom.CAS(2+4i, -1.5i), "CAS didn't report a swap.")
+	require.False(t, atom.CAS(2+4i, 0), "CAS reported a swap with a mismatching old value.")
+	require.Equal(t, complex64(-1.5i), atom.Load(), "CAS didn't set the correct value.")
+
+	require.Equal(t, complex64(-1.5i), atom.Swap(3), "Swap didn't return the old value.")
+	require.Equal(t, complex64(3), atom.Load(), "Swap didn't set the correct value.")
+
+	atom.Store(complex(float32(math.NaN()), 1))
+	require.True(t, atom.CAS(atom.Load(), 0), "CAS didn't match a stored NaN.")
+	require.Equal(t, "(0+0i)", atom.String(), "String didn't encode the value.")
+}
+
+func TestComplex64ConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		adds       = 1000
+	)
+
+	var (
+		atom Complex64
+		wg   sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < adds; j++ {
+				atom.Add(1 - 2i)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, complex64(goroutines*adds*(1-2i)), atom.Load(), "concurrent Add lost additions.")
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// CondValue is a value of type T that goroutines may block on until it satisfies a condition. Load is lock-free,
+// while Store and WaitUntil synchronise through a sync.Cond, so that waiters sleep until a Store wakes them up
+// instead of spinning. CondValues must be created using NewCondValue.
+type CondValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v    Value[T]
+	mu   sync.Mutex
+	cond sync.Cond
+}
+
+// NewCondValue creates a new CondValue holding val.
+func NewCondValue[T any](val T) *CondValue[T] {
+	c := &CondValue[T]{}
+	c.cond.L = &c.mu
+	c.v.Store(val)
+	return c
+}
+
+// Load atomically loads the value held by the CondValue without blocking.
+func (c *CondValue[T]) Load() T {
+	return c.v.Load()
+}
+
+// Store atomically stores val and wakes up all goroutines blocked in WaitUntil to check their condition.
+func (c *CondValue[T]) Store(val T) {
+	c.mu.Lock()
+	c.v.Store(val)
+	c.mu.Unlock()
+	c.cond.Broadcast()
+}
+
+// WaitUntil blocks until the value held by the CondValue satisfies pred, and returns that value. pred is called with
+// the value held when WaitUntil is called and after every Store until it returns true. WaitUntil returns immediately
+// if the value already satisfies pred.
+func (c *CondValue[T]) WaitUntil(pred func(T) bool) T {
+	if val := c.v.Load(); pred(val) {
+		return val
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		if val := c.v.Load(); pred(val) {
+			return val
+		}
+		c.cond.Wait()
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCondValue(t *testing.T) {
+	c := NewCondValue(1)
+	assert.Equal(t, 1, c.Load(), "Load didn't return the initial value.")
+	assert.Equal(t, 1, c.WaitUntil(func(v int) bool { return v == 1 }),
+		"WaitUntil of a satisfied condition didn't return the value.")
+
+	c.Store(2)
+	assert.Equal(t, 2, c.Load(), "Load didn't return the stored value.")
+}
+
+func TestCondValueWaiters(t *testing.T) {
+	const waiters = 4
+
+	var (
+		c     = NewCondValue(0)
+		calls [waiters]Int32
+		wg    sync.WaitGroup
+	)
+	wg.Add(waiters)
+	for i := 0; i < waiters; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			v := c.WaitUntil(func(v int) bool {
+				calls[i].Inc()
+				return v >= 3
+			})
+			assert.Equal(t, 3, v, "WaitUntil returned a value not satisfying the condition.")
+		}()
+	}
+
+	// Give the waiters time to block, so that any spinning would be visible in the number of predicate calls.
+	time.Sleep(10 * time.Millisecond)
+	for v := 1; v <= 3; v++ {
+		c.Store(v)
+	}
+	wg.Wait()
+
+	for i := range calls {
+		// At most one call before blocking, one after locking and one per Store.
+		assert.True(t, calls[i].Load() <= 5, "waiter %v called its predicate too often", i)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Counter is an int64 counter that, in addition to its current value, reports how much it advanced since it was last
+// read using DeltaSinceLastRead, for example to compute rates over intervals. The zero value is a counter at 0.
+type Counter struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v    Int64
+	prev Int64
+}
+
+// NewCounter creates a new Counter holding val. The first call to DeltaSinceLastRead reports the advance since val.
+func NewCounter(val int64) *Counter {
+	c := &Counter{}
+	c.v.Store(val)
+	c.prev.Store(val)
+	return c
+}
+
+// Add atomically adds delta to the Counter and returns the new value.
+func (c *Counter) Add(delta int64) int64 {
+	return c.v.Add(delta)
+}
+
+// Inc atomically increments the Counter and returns the new value.
+func (c *Counter) Inc() int64 {
+	return c.v.Inc()
+}
+
+// Load atomically loads the current value of the Counter.
+func (c *Counter) Load() int64 {
+	return c.v.Load()
+}
+
+// DeltaSinceLastRead returns how much the Counter advanced since the previous call to DeltaSinceLastRead, or since
+// it was created if there was none, and records the current value for the next call.
+//
+// Concurrent calls to DeltaSinceLastRead split the advance between them: the deltas returned always add up to the
+// total advance of the Counter, but an individual delta may include increments that another call also observed and
+// compensated for, and may even be negative.
+func (c *Counter) DeltaSinceLastRead() int64 {
+	current := c.v.Load()
+	return current - c.prev.Swap(current)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounter(10)
+	assert.Equal(t, int64(0), c.DeltaSinceLastRead(), "DeltaSinceLastRead of a new Counter wasn't 0.")
+	assert.Equal(t, int64(15), c.Add(5), "Add didn't return the new value.")
+	assert.Equal(t, int64(16), c.Inc(), "Inc didn't return the new value.")
+	assert.Equal(t, int64(6), c.DeltaSinceLastRead(), "DeltaSinceLastRead didn't return the advance.")
+	assert.Equal(t, int64(0), c.DeltaSinceLastRead(), "DeltaSinceLastRead didn't record the previous value.")
+	assert.Equal(t, int64(16), c.Load(), "DeltaSinceLastRead modified the Counter.")
+}
+
+func TestCounterConcurrentDeltas(t *testing.T) {
+	const (
+		goroutines = 4
+		increments = 1000
+	)
+
+	var (
+		c       Counter
+		total   Int64
+		writers sync.WaitGroup
+		readers sync.WaitGroup
+		done    = make(chan struct{})
+	)
+	writers.Add(goroutines)
+	readers.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer writers.Done()
+			for j := 0; j < increments; j++ {
+				c.Inc()
+			}
+		}()
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					total.Add(c.DeltaSinceLastRead())
+				}
+			}
+		}()
+	}
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	total.Add(c.DeltaSinceLastRead())
+	assert.Equal(t, int64(goroutines*increments), total.Load(), "deltas didn't add up to the total advance.")
+}
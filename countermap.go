@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// CounterMap is a map of int64 counters keyed by K. Incrementing the counter of a key that is already present, as
+// well as loading counters, is lock-free. Adding a key that is not yet present copies the map and is serialised with
+// other insertions, so CounterMap is best suited for a key space that is mostly known after warm-up.
+type CounterMap[K comparable] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	mu sync.Mutex
+	m  Value[map[K]*Int64]
+}
+
+// NewCounterMap creates a new, empty CounterMap.
+func NewCounterMap[K comparable]() *CounterMap[K] {
+	return &CounterMap[K]{}
+}
+
+// Add atomically adds delta to the counter of key and returns the new value. A counter that does not yet exist is
+// created with a value of zero first.
+func (c *CounterMap[K]) Add(key K, delta int64) int64 {
+	if counter, ok := c.m.Load()[key]; ok {
+		return counter.Add(delta)
+	}
+	return c.insert(key).Add(delta)
+}
+
+// insert returns the counter of key, inserting a new counter into a copy of the map if it does not yet exist.
+func (c *CounterMap[K]) insert(key K) *Int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := c.m.Load()
+	if counter, ok := current[key]; ok {
+		return counter
+	}
+	m := make(map[K]*Int64, len(current)+1)
+	for k, counter := range current {
+		m[k] = counter
+	}
+	counter := &Int64{}
+	m[key] = counter
+	c.m.Store(m)
+	return counter
+}
+
+// Load atomically loads the counter of key. Load returns zero if no counter exists for key.
+func (c *CounterMap[K]) Load(key K) int64 {
+	if counter, ok := c.m.Load()[key]; ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// Snapshot returns a map holding the values of all counters in the CounterMap. Every counter is loaded atomically,
+// but the map returned is not a consistent snapshot across keys if counters are modified concurrently.
+func (c *CounterMap[K]) Snapshot() map[K]int64 {
+	current := c.m.Load()
+	m := make(map[K]int64, len(current))
+	for k, counter := range current {
+		m[k] = counter.Load()
+	}
+	return m
+}
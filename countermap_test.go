@@ -0,0 +1,98 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterMap(t *testing.T) {
+	c := NewCounterMap[string]()
+	require.Equal(t, int64(0), c.Load("foo"), "Load of a missing key didn't return zero.")
+	require.Equal(t, int64(2), c.Add("foo", 2), "Add didn't return the new value.")
+	require.Equal(t, int64(-1), c.Add("bar", -1), "Add didn't return the new value.")
+	require.Equal(t, int64(5), c.Add("foo", 3), "Add didn't return the new value.")
+	require.Equal(t, int64(5), c.Load("foo"), "Load didn't return the correct value.")
+	require.Equal(t, map[string]int64{"foo": 5, "bar": -1}, c.Snapshot(), "Snapshot didn't return all counters.")
+
+	t.Run("zero value", func(t *testing.T) {
+		var c CounterMap[int]
+		assert.Empty(t, c.Snapshot(), "Snapshot of an empty CounterMap wasn't empty.")
+		assert.Equal(t, int64(1), c.Add(1, 1), "Add didn't return the new value.")
+	})
+}
+
+func TestCounterMapConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		increments = 1000
+	)
+
+	t.Run("disjoint keys", func(t *testing.T) {
+		var (
+			c  CounterMap[string]
+			wg sync.WaitGroup
+		)
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			key := fmt.Sprint(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < increments; j++ {
+					c.Add(key, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		snapshot := c.Snapshot()
+		require.Len(t, snapshot, goroutines, "Snapshot didn't hold a counter for every key.")
+		for key, v := range snapshot {
+			assert.Equal(t, int64(increments), v, "counter %q has the wrong value", key)
+		}
+	})
+
+	t.Run("overlapping keys", func(t *testing.T) {
+		var (
+			c  CounterMap[int]
+			wg sync.WaitGroup
+		)
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < increments; j++ {
+					c.Add(j%4, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		for key := 0; key < 4; key++ {
+			assert.Equal(t, int64(goroutines*increments/4), c.Load(key), "counter %v has the wrong value", key)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// COWList is an ordered list of values of type T. COWList is backed by a copy-on-write slice: Load and Len are
+// lock-free, and Append, Prepend and RemoveFunc atomically publish a modified copy of the slice, retrying if another
+// writer published one first. This makes COWList suitable for read-mostly lists, such as a chain of middleware, that
+// change rarely. The zero value is an empty COWList.
+type COWList[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	s Value[*[]T]
+}
+
+// NewCOWList creates a new COWList holding the values passed, in order.
+func NewCOWList[T any](vals ...T) *COWList[T] {
+	l := &COWList[T]{}
+	s := append(make([]T, 0, len(vals)), vals...)
+	l.s.Store(&s)
+	return l
+}
+
+// Load returns a snapshot of the values in the COWList at the time of the call. The slice returned is shared with
+// other readers and must not be modified. Appending to it is safe, as its capacity never exceeds its length.
+func (l *COWList[T]) Load() []T {
+	if s := l.s.Load(); s != nil {
+		return *s
+	}
+	return nil
+}
+
+// Len returns the number of values in the COWList.
+func (l *COWList[T]) Len() int {
+	return len(l.Load())
+}
+
+// Append atomically adds v to the end of the COWList.
+func (l *COWList[T]) Append(v T) {
+	l.s.update(func(old *[]T) (*[]T, bool) {
+		s := copyCOWList(old, 1)
+		s = append(s, v)
+		return &s, true
+	})
+}
+
+// Prepend atomically adds v to the start of the COWList.
+func (l *COWList[T]) Prepend(v T) {
+	l.s.update(func(old *[]T) (*[]T, bool) {
+		s := make([]T, 1, 1+len(derefCOWList(old)))
+		s[0] = v
+		s = append(s, derefCOWList(old)...)
+		return &s, true
+	})
+}
+
+// RemoveFunc atomically removes all values from the COWList for which pred returns true, and reports whether any
+// value was removed. pred may be called more than once for the same value if another writer modifies the COWList
+// concurrently.
+func (l *COWList[T]) RemoveFunc(pred func(T) bool) (removed bool) {
+	_, removed = l.s.update(func(old *[]T) (*[]T, bool) {
+		vals := derefCOWList(old)
+		s := make([]T, 0, len(vals))
+		for _, v := range vals {
+			if !pred(v) {
+				s = append(s, v)
+			}
+		}
+		if len(s) == len(vals) {
+			return nil, false
+		}
+		// Trim the capacity so that appending to a snapshot returned by Load never writes to a shared array.
+		s = s[:len(s):len(s)]
+		return &s, true
+	})
+	return removed
+}
+
+// derefCOWList returns the slice s points to, or nil if s is nil.
+func derefCOWList[T any](s *[]T) []T {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// copyCOWList copies the slice s points to, which may be nil, with room for extra additional values.
+func copyCOWList[T any](s *[]T, extra int) []T {
+	vals := derefCOWList(s)
+	return append(make([]T, 0, len(vals)+extra), vals...)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOWList(t *testing.T) {
+	l := NewCOWList(2, 3)
+	l.Append(4)
+	l.Prepend(1)
+	require.Equal(t, []int{1, 2, 3, 4}, l.Load(), "Append and Prepend didn't keep the values in order.")
+
+	snapshot := l.Load()
+	require.True(t, l.RemoveFunc(func(v int) bool { return v%2 == 0 }), "RemoveFunc didn't report matching values as removed.")
+	require.False(t, l.RemoveFunc(func(v int) bool { return v > 10 }), "RemoveFunc reported missing values as removed.")
+	require.Equal(t, []int{1, 3}, l.Load(), "RemoveFunc didn't remove the matching values.")
+	require.Equal(t, []int{1, 2, 3, 4}, snapshot, "RemoveFunc modified a snapshot returned by Load.")
+	require.Equal(t, 2, l.Len(), "Len returned the wrong number of values.")
+
+	_ = append(l.Load(), 5)
+	require.Equal(t, []int{1, 3}, l.Load(), "appending to a snapshot modified the COWList.")
+
+	t.Run("zero value", func(t *testing.T) {
+		var l COWList[string]
+		assert.Empty(t, l.Load(), "Load of an empty COWList wasn't empty.")
+		assert.False(t, l.RemoveFunc(func(string) bool { return true }), "RemoveFunc of an empty COWList returned true.")
+		l.Prepend("foo")
+		assert.Equal(t, []string{"foo"}, l.Load(), "Prepend to an empty COWList didn't add the value.")
+	})
+}
+
+func TestCOWListConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		values     = 100
+	)
+
+	var (
+		l  COWList[int]
+		wg sync.WaitGroup
+	)
+	wg.Add(goroutines * 2)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			for v := 0; v < values; v++ {
+				if v%2 == 0 {
+					l.Append(i*values + v)
+				} else {
+					l.Prepend(i*values + v)
+				}
+				x := i*values + v
+				if v%4 == 0 {
+					assert.True(t, l.RemoveFunc(func(o int) bool { return o == x }), "RemoveFunc didn't find a value added.")
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for v := 0; v < values; v++ {
+				seen := map[int]bool{}
+				for _, o := range l.Load() {
+					assert.False(t, seen[o], "snapshot held a duplicate value.")
+					seen[o] = true
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*values*3/4, l.Len(), "values were lost under concurrent writes.")
+}
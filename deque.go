@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "unsafe"
+
+// Deque is a lock-free, work-stealing double-ended queue as described by Chase and Lev in "Dynamic Circular
+// Work-Stealing Deque". A single owner goroutine pushes and pops values at the bottom of the Deque, while any number
+// of other goroutines may concurrently steal values from its top.
+//
+// PushBottom and PopBottom must only ever be called by the owner of the Deque. Steal may be called by any goroutine.
+type Deque[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	top, bottom Int64
+	ring        Value[*dequeRing]
+}
+
+// dequeRing is a circular buffer of pointers to the values in a Deque. The length of its slots is always a power of
+// two.
+type dequeRing struct {
+	slots []UnsafePointer
+}
+
+// _dequeMinSize is the number of slots in the first ring allocated by a Deque.
+const _dequeMinSize = 32
+
+// get atomically loads the pointer stored at index i.
+func (r *dequeRing) get(i int64) unsafe.Pointer {
+	return r.slots[i&int64(len(r.slots)-1)].Load()
+}
+
+// put atomically stores p at index i.
+func (r *dequeRing) put(i int64, p unsafe.Pointer) {
+	r.slots[i&int64(len(r.slots)-1)].Store(p)
+}
+
+// grow returns a ring twice the size of r, or of _dequeMinSize if r is nil, holding the values from index t up to b.
+func (r *dequeRing) grow(b, t int64) *dequeRing {
+	size := _dequeMinSize
+	if r != nil {
+		size = len(r.slots) * 2
+	}
+	next := &dequeRing{slots: make([]UnsafePointer, size)}
+	for i := t; i < b; i++ {
+		next.put(i, r.get(i))
+	}
+	return next
+}
+
+// PushBottom pushes v to the bottom of the Deque. PushBottom must only be called by the owner of the Deque.
+func (d *Deque[T]) PushBottom(v T) {
+	b, t := d.bottom.Load(), d.top.Load()
+	r := d.ring.Load()
+	if r == nil || b-t >= int64(len(r.slots)) {
+		r = r.grow(b, t)
+		d.ring.Store(r)
+	}
+	r.put(b, unsafe.Pointer(&v))
+	d.bottom.Store(b + 1)
+}
+
+// PopBottom pops the value at the bottom of the Deque, which is the value most recently pushed, and reports whether a
+// value was popped. PopBottom returns false if the Deque is empty, or if its last value was stolen concurrently.
+// PopBottom must only be called by the owner of the Deque.
+func (d *Deque[T]) PopBottom() (T, bool) {
+	var zero T
+
+	b := d.bottom.Load() - 1
+	r := d.ring.Load()
+	d.bottom.Store(b)
+	t := d.top.Load()
+	if t > b {
+		// The Deque was empty.
+		d.bottom.Store(b + 1)
+		return zero, false
+	}
+	p := r.get(b)
+	if t == b {
+		// This is the last value in the Deque, which a thief might be stealing concurrently.
+		won := d.top.CAS(t, t+1)
+		d.bottom.Store(b + 1)
+		if !won {
+			return zero, false
+		}
+	}
+	return *(*T)(p), true
+}
+
+// Steal steals the value at the top of the Deque, which is the value least recently pushed, and reports whether a
+// value was stolen. Steal returns false if the Deque is empty. Steal may be called concurrently by any goroutine.
+func (d *Deque[T]) Steal() (T, bool) {
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+		if t >= b {
+			var zero T
+			return zero, false
+		}
+		p := d.ring.Load().get(t)
+		if d.top.CAS(t, t+1) {
+			return *(*T)(p), true
+		}
+		// Another thief, or the owner popping the last value, won the race for index t.
+	}
+}
+
+// Len returns the number of values in the Deque. The result is a snapshot that may be outdated by the time it is
+// returned if the Deque is modified concurrently.
+func (d *Deque[T]) Len() int {
+	if n := d.bottom.Load() - d.top.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
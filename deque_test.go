@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	_, ok := d.PopBottom()
+	require.False(t, ok, "PopBottom of an empty Deque returned a value.")
+	_, ok = d.Steal()
+	require.False(t, ok, "Steal of an empty Deque returned a value.")
+
+	for i := 0; i < 100; i++ {
+		d.PushBottom(i)
+	}
+	require.Equal(t, 100, d.Len(), "Len returned the wrong number of values.")
+
+	v, ok := d.PopBottom()
+	require.True(t, ok, "PopBottom didn't return a value.")
+	require.Equal(t, 99, v, "PopBottom didn't return the most recently pushed value.")
+
+	v, ok = d.Steal()
+	require.True(t, ok, "Steal didn't return a value.")
+	require.Equal(t, 0, v, "Steal didn't return the least recently pushed value.")
+
+	for want := 98; want >= 1; want-- {
+		v, ok := d.PopBottom()
+		require.True(t, ok, "PopBottom didn't return a value.")
+		require.Equal(t, want, v, "PopBottom returned values out of order.")
+	}
+	_, ok = d.PopBottom()
+	assert.False(t, ok, "PopBottom of an emptied Deque returned a value.")
+	assert.Zero(t, d.Len(), "Len of an emptied Deque wasn't zero.")
+}
+
+func TestDequeStress(t *testing.T) {
+	const (
+		stealers = 4
+		values   = 10000
+	)
+
+	var (
+		d     Deque[int]
+		taken [values]Int32
+		wg    sync.WaitGroup
+		done  = make(chan struct{})
+	)
+	take := func(v int) {
+		if taken[v].Inc() != 1 {
+			t.Errorf("value %v was taken more than once", v)
+		}
+	}
+
+	wg.Add(stealers)
+	for i := 0; i < stealers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				if v, ok := d.Steal(); ok {
+					take(v)
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < values; i++ {
+		d.PushBottom(i)
+		if i%3 == 0 {
+			if v, ok := d.PopBottom(); ok {
+				take(v)
+			}
+		}
+	}
+	for {
+		v, ok := d.PopBottom()
+		if !ok {
+			break
+		}
+		take(v)
+	}
+	close(done)
+	wg.Wait()
+
+	for v := range taken {
+		assert.Equal(t, int32(1), taken[v].Load(), "value %v was not taken exactly once", v)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// DoubleBuffer holds two buffers of type T, one of which is the front buffer that is read by readers and the other
+// the back buffer that is filled by a writer. Swap publishes the back buffer as the new front buffer, after which the
+// previous front buffer becomes the back buffer, so that no allocation is needed per update.
+//
+// A DoubleBuffer supports only a single writer: Back and Swap must not be called concurrently. Readers obtaining the
+// front buffer through Front may do so concurrently with the writer, but must be done with it before the writer
+// starts filling the back buffer again after the next Swap, as it is then the same buffer.
+type DoubleBuffer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	bufs  [2]T
+	front Uint32
+}
+
+// NewDoubleBuffer creates a new DoubleBuffer with the front and back buffers passed.
+func NewDoubleBuffer[T any](front, back T) *DoubleBuffer[T] {
+	return &DoubleBuffer[T]{bufs: [2]T{front, back}}
+}
+
+// Front atomically loads the current front buffer. Front is safe to call concurrently with a writer.
+func (b *DoubleBuffer[T]) Front() *T {
+	return &b.bufs[b.front.Load()]
+}
+
+// Back returns the current back buffer. Only the writer may call Back and modify the buffer it returns.
+func (b *DoubleBuffer[T]) Back() *T {
+	return &b.bufs[b.front.Load()^1]
+}
+
+// Swap atomically publishes the back buffer as the new front buffer. The previous front buffer becomes the back
+// buffer. Only the writer may call Swap.
+func (b *DoubleBuffer[T]) Swap() {
+	b.front.Store(b.front.Load() ^ 1)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleBuffer(t *testing.T) {
+	b := NewDoubleBuffer(1, 2)
+	require.Equal(t, 1, *b.Front(), "Front didn't return the initial front buffer.")
+	require.Equal(t, 2, *b.Back(), "Back didn't return the initial back buffer.")
+
+	*b.Back() = 3
+	b.Swap()
+	require.Equal(t, 3, *b.Front(), "Swap didn't publish the back buffer.")
+	require.Equal(t, 1, *b.Back(), "Swap didn't turn the front buffer into the back buffer.")
+
+	t.Run("zero value", func(t *testing.T) {
+		var b DoubleBuffer[int]
+		*b.Back() = 5
+		assert.Equal(t, 0, *b.Front(), "Back modified the front buffer.")
+		b.Swap()
+		assert.Equal(t, 5, *b.Front(), "Swap didn't publish the back buffer.")
+	})
+}
+
+func TestDoubleBufferReaders(t *testing.T) {
+	const (
+		size        = 64
+		generations = 100
+		readers     = 4
+		passes      = 10
+	)
+
+	b := NewDoubleBuffer(make([]int, size), make([]int, size))
+	read := func(gen int) {
+		for pass := 0; pass < passes; pass++ {
+			front := *b.Front()
+			// A reader may load the front buffer after the next Swap, so it may observe a later generation, but
+			// never one that is not fully populated.
+			if front[0] < gen {
+				t.Errorf("front buffer holds generation %v, want at least %v", front[0], gen)
+				return
+			}
+			for j, v := range front {
+				if v != front[0] {
+					t.Errorf("front buffer element %v = %v, want %v", j, v, front[0])
+					return
+				}
+			}
+		}
+	}
+
+	// The readers of every generation run concurrently with the writer filling the back buffer with the next
+	// generation and swapping it in. They are waited for before the writer fills the buffer they read again, after
+	// the next Swap.
+	var prev sync.WaitGroup
+	for gen := 1; gen <= generations; gen++ {
+		back := *b.Back()
+		for i := range back {
+			back[i] = gen
+		}
+		b.Swap()
+		prev.Wait()
+
+		prev.Add(readers)
+		for i := 0; i < readers; i++ {
+			gen := gen
+			go func() {
+				defer prev.Done()
+				read(gen)
+			}()
+		}
+	}
+	prev.Wait()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"math"
+)
+
+// EWMA is an exponentially-weighted moving average of float64 samples that is updated atomically. EWMAs must be
+// created using NewEWMA.
+type EWMA struct {
+	_ nocmp // disallow non-atomic comparison
+
+	alpha float64
+	// avg holds NaN until the first sample is added, and never afterwards, as Update rejects samples that would make
+	// it NaN.
+	avg Float64
+}
+
+// NewEWMA creates a new EWMA that weighs every new sample by alpha, and the previous average by 1-alpha. A higher
+// alpha makes the average follow recent samples more closely. NewEWMA panics if alpha is not in the range (0, 1].
+func NewEWMA(alpha float64) *EWMA {
+	if !(alpha > 0 && alpha <= 1) {
+		panic(fmt.Sprintf("atomic: EWMA alpha %v out of range (0, 1]", alpha))
+	}
+	e := &EWMA{alpha: alpha}
+	e.avg.Store(math.NaN())
+	return e
+}
+
+// Update atomically adds sample to the EWMA and returns the new average. The first sample added sets the average to
+// the sample itself. Update rejects samples that would make the average NaN, which are NaN samples and infinite samples
+// opposite to an infinite average, and returns the current average without adding them, like Load.
+func (e *EWMA) Update(sample float64) float64 {
+	for {
+		old := e.avg.Load()
+		new := sample
+		if !math.IsNaN(old) {
+			new = e.alpha*sample + (1-e.alpha)*old
+		}
+		// NaN marks an EWMA without samples, so storing it would silently discard all samples added before.
+		if math.IsNaN(new) {
+			return e.Load()
+		}
+		if e.avg.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// Load atomically loads the current average. Load returns 0 if no samples were added.
+func (e *EWMA) Load() float64 {
+	if avg := e.avg.Load(); !math.IsNaN(avg) {
+		return avg
+	}
+	return 0
+}
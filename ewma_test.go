@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEWMA(t *testing.T) {
+	e := NewEWMA(0.5)
+	assert.Equal(t, 0.0, e.Load(), "Load without samples didn't return 0.")
+	assert.Equal(t, 4.0, e.Update(4), "first Update didn't set the average to the sample.")
+	assert.Equal(t, 3.0, e.Update(2), "Update didn't weigh the sample by alpha.")
+	assert.Equal(t, 3.0, e.Load(), "Load didn't return the average.")
+
+	assert.Equal(t, 3.0, e.Update(math.NaN()), "Update didn't reject a NaN sample.")
+	assert.Equal(t, 3.0, e.Load(), "NaN sample modified the average.")
+
+	inf := NewEWMA(0.5)
+	assert.Equal(t, 0.0, inf.Update(math.NaN()), "Update of an EWMA without samples didn't reject a NaN sample.")
+	assert.Equal(t, math.Inf(1), inf.Update(math.Inf(1)), "Update didn't set the average to an infinite sample.")
+	assert.Equal(t, math.Inf(1), inf.Update(math.Inf(-1)), "Update didn't reject an opposite infinite sample.")
+	assert.Equal(t, math.Inf(1), inf.Update(1), "Update of an infinite average didn't stay infinite.")
+
+	for _, alpha := range []float64{0, -1, 1.5} {
+		assert.Panics(t, func() { NewEWMA(alpha) }, "NewEWMA didn't panic for alpha %v.", alpha)
+	}
+}
+
+func TestEWMAConvergence(t *testing.T) {
+	const goroutines = 4
+
+	e := NewEWMA(0.1)
+	e.Update(0)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				e.Update(10)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.InDelta(t, 10, e.Load(), 1e-9, "EWMA didn't converge toward a constant input.")
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "time"
+
+// ExpiringValue is a value of type T that expires a fixed duration after it was stored. Expiry is checked lazily
+// when loading the value, by comparing the time the value was stored at with the current time. ExpiringValues must
+// be created using NewExpiringValue.
+type ExpiringValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	ttl time.Duration
+	now func() time.Time
+	v   Value[*expiringEntry[T]]
+}
+
+// expiringEntry holds a value stored to an ExpiringValue along with the time it was stored at.
+type expiringEntry[T any] struct {
+	val      T
+	storedAt time.Time
+}
+
+// NewExpiringValue creates a new, unset ExpiringValue whose values expire ttl after they are stored.
+func NewExpiringValue[T any](ttl time.Duration) *ExpiringValue[T] {
+	return &ExpiringValue[T]{ttl: ttl, now: time.Now}
+}
+
+// Store atomically stores val, recording the current time as the time it was stored at.
+func (e *ExpiringValue[T]) Store(val T) {
+	e.v.Store(&expiringEntry[T]{val: val, storedAt: e.now()})
+}
+
+// Load atomically loads the value held and reports whether it is still valid. Load returns the zero value of T and
+// false if no value was stored yet, or if the value stored expired, which is the case once ttl or more has elapsed
+// since it was stored.
+func (e *ExpiringValue[T]) Load() (val T, ok bool) {
+	entry := e.v.Load()
+	if entry == nil || e.now().Sub(entry.storedAt) >= e.ttl {
+		return val, false
+	}
+	return entry.val, true
+}
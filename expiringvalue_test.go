@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiringValue(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		clock = NewValue(start)
+		e     = NewExpiringValue[string](time.Minute)
+	)
+	e.now = clock.Load
+
+	_, ok := e.Load()
+	assert.False(t, ok, "Load of an unset ExpiringValue reported a value.")
+
+	e.Store("token")
+	val, ok := e.Load()
+	assert.True(t, ok, "Load of a fresh value reported it expired.")
+	assert.Equal(t, "token", val, "Load didn't return the value stored.")
+
+	clock.Store(start.Add(time.Minute - time.Nanosecond))
+	_, ok = e.Load()
+	assert.True(t, ok, "Load just before the TTL elapsed reported the value expired.")
+
+	clock.Store(start.Add(time.Minute))
+	val, ok = e.Load()
+	assert.False(t, ok, "Load once the TTL elapsed reported a value.")
+	assert.Equal(t, "", val, "Load of an expired value didn't return the zero value.")
+
+	e.Store("refreshed")
+	val, ok = e.Load()
+	assert.True(t, ok && val == "refreshed", "Store didn't refresh the expiry.")
+}
+
+func TestExpiringValueConcurrentLoads(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		clock = NewValue(start)
+		e     = NewExpiringValue[int](time.Second)
+		wg    sync.WaitGroup
+	)
+	e.now = clock.Load
+	e.Store(1)
+
+	wg.Add(8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer wg.Done()
+			expired := false
+			for j := 0; j < 1000; j++ {
+				val, ok := e.Load()
+				if ok {
+					assert.False(t, expired, "Load reported a value after it expired.")
+					assert.Equal(t, 1, val, "Load returned the wrong value.")
+				}
+				expired = expired || !ok
+			}
+		}()
+	}
+	clock.Store(start.Add(time.Second))
+	wg.Wait()
+
+	_, ok := e.Load()
+	assert.False(t, ok, "Load reported a value after it expired.")
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// FlipFlop alternates between two values, a and b, passed when it is created. FlipFlops must be created using
+// NewFlipFlop.
+type FlipFlop[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	vals [2]T
+	isB  Bool
+}
+
+// NewFlipFlop creates a new FlipFlop that alternates between a and b, starting at a.
+func NewFlipFlop[T any](a, b T) *FlipFlop[T] {
+	return &FlipFlop[T]{vals: [2]T{a, b}}
+}
+
+// Load atomically loads the current value of the FlipFlop.
+func (f *FlipFlop[T]) Load() T {
+	return f.vals[boolToInt(f.isB.Load())]
+}
+
+// Flip atomically moves the FlipFlop from a to b, or from b to a, and returns the new value. Concurrent calls to Flip
+// are applied one after another, so that every call moves the FlipFlop to the value the previous call moved it away
+// from.
+func (f *FlipFlop[T]) Flip() T {
+	return f.vals[boolToInt(!f.isB.Toggle())]
+}
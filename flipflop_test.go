@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlipFlop(t *testing.T) {
+	f := NewFlipFlop("a", "b")
+	require.Equal(t, "a", f.Load(), "FlipFlop didn't start at a.")
+	require.Equal(t, "b", f.Flip(), "Flip didn't move from a to b.")
+	require.Equal(t, "b", f.Load(), "Load didn't return the flipped value.")
+	require.Equal(t, "a", f.Flip(), "Flip didn't move from b to a.")
+	require.Equal(t, "a", f.Load(), "Load didn't return the flipped value.")
+}
+
+func TestFlipFlopConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		flips      = 1001
+	)
+
+	var (
+		f      = NewFlipFlop("a", "b")
+		counts [2]Int64
+		wg     sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < flips; j++ {
+				if f.Flip() == "a" {
+					counts[0].Inc()
+				} else {
+					counts[1].Inc()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// An even number of flips in total leaves the FlipFlop at a, having returned a and b equally often.
+	assert.Equal(t, "a", f.Load(), "FlipFlop didn't end at a after an even number of flips.")
+	assert.Equal(t, int64(goroutines*flips/2), counts[0].Load(), "Flip returned a an unexpected number of times.")
+	assert.Equal(t, int64(goroutines*flips/2), counts[1].Load(), "Flip returned b an unexpected number of times.")
+}
@@ -58,10 +58,50 @@ func (f *Float64) Sub(delta float64) float64 {
 //   }
 //
 // If CAS did not match NaN to match, then the above would loop forever.
+//
+// CAS compares the bits of the values, so that -0.0 and +0.0 do not compare
+// equal either. Use CompareAndSwapValue to compare by numeric equality instead.
 func (f *Float64) CAS(old, new float64) (swapped bool) {
 	return f.v.CAS(math.Float64bits(old), math.Float64bits(new))
 }
 
+// CompareAndSwapValue is an atomic compare-and-swap for float64 values that,
+// unlike CAS, compares by numeric equality using Go's == operator. -0.0 and
+// +0.0 compare equal, and a stored NaN never compares equal to old, so that
+// CompareAndSwapValue always fails while the wrapped value is NaN.
+func (f *Float64) CompareAndSwapValue(old, new float64) (swapped bool) {
+	for {
+		current := f.Load()
+		if current != old {
+			return false
+		}
+		if f.v.CAS(math.Float64bits(current), math.Float64bits(new)) {
+			return true
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped float64 and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (f *Float64) GreaterThan(v float64) bool {
+	return f.Load() > v
+}
+
+// LessThan atomically loads the wrapped float64 and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (f *Float64) LessThan(v float64) bool {
+	return f.Load() < v
+}
+
+// Between atomically loads the wrapped float64 and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot. Like the comparison operators, Between
+// returns false if the value is NaN.
+func (f *Float64) Between(lo, hi float64) bool {
+	v := f.Load()
+	return v >= lo && v <= hi
+}
+
 // String encodes the wrapped value as a string.
 func (f *Float64) String() string {
 	// 'g' is the behavior for floats with %v.
@@ -22,6 +22,7 @@ package atomic
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -65,8 +66,47 @@ func TestFloat64(t *testing.T) {
 			"json.Unmarshal failed with unexpected error %v, want UnmarshalTypeError.", err)
 	})
 
+	t.Run("JSON/Marshal/Unsupported", func(t *testing.T) {
+		for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			_, err := json.Marshal(NewFloat64(v))
+			require.Error(t, err, "json.Marshal of %v didn't error as expected.", v)
+			assertErrorAsType(t, err, new(*json.UnsupportedValueError),
+				"json.Marshal failed with unexpected error %v, want UnsupportedValueError.", err)
+		}
+	})
+
 	t.Run("String", func(t *testing.T) {
 		assert.Equal(t, "42.5", NewFloat64(42.5).String(),
 			"String() returned an unexpected value.")
 	})
 }
+
+func TestFloat64Comparisons(t *testing.T) {
+	atom := NewFloat64(0.5)
+	assert.True(t, atom.GreaterThan(-0.5), "GreaterThan of a smaller value returned false.")
+	assert.False(t, atom.GreaterThan(0.5), "GreaterThan of an equal value returned true.")
+	assert.True(t, atom.LessThan(1), "LessThan of a greater value returned false.")
+	assert.False(t, atom.LessThan(0.5), "LessThan of an equal value returned true.")
+	assert.True(t, atom.Between(0, 1), "Between of surrounding bounds returned false.")
+	assert.False(t, atom.Between(1, 2), "Between of greater bounds returned true.")
+
+	atom.Store(math.NaN())
+	assert.False(t, atom.Between(math.Inf(-1), math.Inf(1)), "Between of NaN returned true.")
+}
+
+func TestFloat64CompareAndSwapValue(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	var atom Float64
+	atom.Store(negZero)
+	assert.False(t, atom.CAS(0, 1), "CAS of +0.0 matched a stored -0.0.")
+	assert.True(t, atom.CompareAndSwapValue(0, 1), "CompareAndSwapValue of +0.0 didn't match a stored -0.0.")
+	assert.Equal(t, 1.0, atom.Load(), "CompareAndSwapValue didn't store the new value.")
+	assert.False(t, atom.CompareAndSwapValue(2, 3), "CompareAndSwapValue of a mismatching value swapped.")
+
+	atom.Store(math.NaN())
+	assert.False(t, atom.CompareAndSwapValue(math.NaN(), 1), "CompareAndSwapValue matched a stored NaN.")
+	assert.True(t, math.IsNaN(atom.Load()), "failed CompareAndSwapValue modified the value.")
+	assert.True(t, atom.CAS(math.NaN(), 1), "CAS didn't match a stored NaN.")
+	assert.Equal(t, 1.0, atom.Load(), "CAS didn't store the new value.")
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "context"
+
+// Future is a write-once, read-many slot for a value of type T that is resolved at some point in the future. Readers
+// calling Await block until the Future is resolved. Futures must be created using NewFuture.
+type Future[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v        Value[T]
+	resolved Bool
+	done     chan struct{}
+}
+
+// NewFuture creates a new, unresolved Future.
+func NewFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Resolve resolves the Future with val and wakes up all callers of Await. Only the first call to Resolve has an
+// effect: subsequent calls leave the Future unchanged and return false.
+func (f *Future[T]) Resolve(val T) (resolved bool) {
+	if !f.resolved.CAS(false, true) {
+		return false
+	}
+	f.v.Store(val)
+	close(f.done)
+	return true
+}
+
+// Await blocks until the Future is resolved and returns the value it was resolved with. If ctx is cancelled before
+// the Future is resolved, Await returns the zero value of T and ctx.Err(). Await returns immediately if the Future
+// was already resolved.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.v.Load(), nil
+	default:
+	}
+
+	select {
+	case <-f.done:
+		return f.v.Load(), nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuture(t *testing.T) {
+	f := NewFuture[string]()
+	require.True(t, f.Resolve("foo"), "Resolve didn't resolve an unresolved Future.")
+	require.False(t, f.Resolve("bar"), "Resolve resolved an already resolved Future.")
+
+	v, err := f.Await(context.Background())
+	require.NoError(t, err, "Await of a resolved Future errored.")
+	require.Equal(t, "foo", v, "Await didn't return the value of the first Resolve.")
+
+	t.Run("resolved ignores cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		v, err := f.Await(ctx)
+		require.NoError(t, err, "Await of a resolved Future errored.")
+		assert.Equal(t, "foo", v, "Await didn't return the resolved value.")
+	})
+}
+
+func TestFutureConcurrentAwait(t *testing.T) {
+	const awaiters = 8
+
+	var (
+		f  = NewFuture[int]()
+		wg sync.WaitGroup
+	)
+	wg.Add(awaiters)
+	for i := 0; i < awaiters; i++ {
+		go func() {
+			defer wg.Done()
+			v, err := f.Await(context.Background())
+			assert.NoError(t, err, "Await errored.")
+			assert.Equal(t, 42, v, "Await didn't return the resolved value.")
+		}()
+	}
+	f.Resolve(42)
+	wg.Wait()
+}
+
+func TestFutureCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	v, err := NewFuture[int]().Await(ctx)
+	require.Equal(t, context.DeadlineExceeded, err, "Await didn't return the context error.")
+	require.Zero(t, v, "Await didn't return the zero value on cancellation.")
+}
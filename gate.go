@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// Gate allows pausing and resuming goroutines that call Wait. While the Gate is open, Wait returns immediately after
+// checking an atomic flag. While it is paused, Wait blocks until Resume is called. The zero value is an open Gate.
+type Gate struct {
+	_ nocmp // disallow non-atomic comparison
+
+	paused Bool
+
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// Pause closes the Gate, making subsequent calls to Wait block until Resume is called. Pausing a Gate that is already
+// paused has no effect.
+func (g *Gate) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused.CAS(false, true) {
+		g.ch = make(chan struct{})
+	}
+}
+
+// Resume opens the Gate, releasing all goroutines blocked in Wait. Resuming a Gate that is open has no effect.
+func (g *Gate) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused.CAS(true, false) {
+		close(g.ch)
+	}
+}
+
+// Paused reports whether the Gate is currently paused.
+func (g *Gate) Paused() bool {
+	return g.paused.Load()
+}
+
+// Wait blocks while the Gate is paused and returns as soon as it is resumed. Wait returns immediately if the Gate is
+// open.
+func (g *Gate) Wait() {
+	if !g.paused.Load() {
+		return
+	}
+
+	g.mu.Lock()
+	if !g.paused.Load() {
+		g.mu.Unlock()
+		return
+	}
+	ch := g.ch
+	g.mu.Unlock()
+	<-ch
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGate(t *testing.T) {
+	var g Gate
+	assert.False(t, g.Paused(), "zero Gate was paused.")
+	g.Wait()
+
+	g.Resume()
+	assert.False(t, g.Paused(), "Resume of an open Gate paused it.")
+
+	g.Pause()
+	g.Pause()
+	assert.True(t, g.Paused(), "Pause didn't pause the Gate.")
+	g.Resume()
+	assert.False(t, g.Paused(), "Resume didn't open the Gate.")
+	g.Wait()
+}
+
+func TestGateWorkers(t *testing.T) {
+	const workers = 4
+
+	var (
+		g       Gate
+		passed  Int32
+		started sync.WaitGroup
+		wg      sync.WaitGroup
+	)
+	g.Pause()
+
+	started.Add(workers)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			started.Done()
+			g.Wait()
+			passed.Inc()
+		}()
+	}
+	started.Wait()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), passed.Load(), "workers passed a paused Gate.")
+
+	g.Resume()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("workers didn't resume after Resume.")
+	}
+	assert.Equal(t, int32(workers), passed.Load(), "not all workers passed the Gate.")
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// GatedValue is a value of type T whose writes only take effect while a gate allows them, for example during a
+// maintenance window. Values stored while the gate is closed are kept pending, and the latest of them is applied by
+// Flush once the gate is open. Load is lock-free and returns the value currently applied, while writes are
+// serialised by a mutex. GatedValues must be created using NewGatedValue.
+type GatedValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	open    func() bool
+	applied Value[T]
+
+	mu      sync.Mutex
+	pending *T
+}
+
+// NewGatedValue creates a new GatedValue with val applied, whose gate is open while open returns true. open is
+// called on every Store and Flush.
+func NewGatedValue[T any](val T, open func() bool) *GatedValue[T] {
+	g := &GatedValue[T]{open: open}
+	g.applied.Store(val)
+	return g
+}
+
+// Load atomically loads the value currently applied.
+func (g *GatedValue[T]) Load() T {
+	return g.applied.Load()
+}
+
+// Store applies val if the gate is open, discarding any value still pending, and reports whether it did so. If the
+// gate is closed, val is kept pending instead, replacing any value pending before, until it is applied by Flush.
+func (g *GatedValue[T]) Store(val T) (applied bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.open() {
+		g.pending = &val
+		return false
+	}
+	g.pending = nil
+	g.applied.Store(val)
+	return true
+}
+
+// Flush applies the latest value stored while the gate was closed, if the gate is open now, and reports whether it
+// applied a value. Flush returns false if the gate is closed or no value is pending.
+func (g *GatedValue[T]) Flush() (applied bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.pending == nil || !g.open() {
+		return false
+	}
+	g.applied.Store(*g.pending)
+	g.pending = nil
+	return true
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatedValue(t *testing.T) {
+	var open Bool
+	g := NewGatedValue("v1", open.Load)
+	assert.Equal(t, "v1", g.Load(), "new GatedValue didn't apply the initial value.")
+
+	assert.False(t, g.Store("v2"), "Store with a closed gate applied the value.")
+	assert.False(t, g.Store("v3"), "Store with a closed gate applied the value.")
+	assert.Equal(t, "v1", g.Load(), "Store with a closed gate changed the applied value.")
+	assert.False(t, g.Flush(), "Flush with a closed gate applied a value.")
+
+	open.Store(true)
+	assert.True(t, g.Flush(), "Flush with an open gate didn't apply the pending value.")
+	assert.Equal(t, "v3", g.Load(), "Flush didn't apply the latest pending value.")
+	assert.False(t, g.Flush(), "Flush without a pending value applied a value.")
+
+	assert.True(t, g.Store("v4"), "Store with an open gate didn't apply the value.")
+	assert.Equal(t, "v4", g.Load(), "Store with an open gate didn't apply the value.")
+
+	open.Store(false)
+	g.Store("v5")
+	open.Store(true)
+	g.Store("v6")
+	assert.False(t, g.Flush(), "Store with an open gate didn't discard the pending value.")
+	assert.Equal(t, "v6", g.Load(), "Flush applied a value older than the one stored.")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// GenerationalValue is a value of type T that allows readers to detect whether it was modified while they were
+// working with a value loaded. Load returns a Generation along with the value, which may be passed to Validate after
+// the reader's computation. If a Store happened in between, Validate returns false and the reader may retry. This is
+// the reader side of a seqlock, without exposing raw sequence numbers. The zero value holds the zero value of T.
+type GenerationalValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*generationEntry[T]]
+}
+
+// generationEntry holds a value stored to a GenerationalValue. Every Store allocates a new entry, so that the entry's
+// address identifies the generation of the value. ok ensures that the entry is never zero-sized, as zero-sized
+// allocations may share an address.
+type generationEntry[T any] struct {
+	val T
+	ok  bool
+}
+
+// Generation identifies the generation of the value held by a GenerationalValue at the time of a Load. The zero
+// Generation is never valid.
+type Generation struct {
+	e any
+}
+
+// Load atomically loads the value held by the GenerationalValue and returns it together with its Generation.
+func (g *GenerationalValue[T]) Load() (val T, gen Generation) {
+	e := g.v.Load()
+	if e != nil {
+		val = e.val
+	}
+	return val, Generation{e: e}
+}
+
+// Store atomically stores val, starting a new generation and invalidating all Generations returned by previous
+// calls to Load.
+func (g *GenerationalValue[T]) Store(val T) {
+	g.v.Store(&generationEntry[T]{val: val, ok: true})
+}
+
+// Validate reports whether gen, as returned by Load, is still the current generation of the GenerationalValue, in
+// other words whether no Store happened since the Load that returned it.
+func (g *GenerationalValue[T]) Validate(gen Generation) bool {
+	return gen.e != nil && gen.e == any(g.v.Load())
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerationalValue(t *testing.T) {
+	var g GenerationalValue[struct{}]
+	_, gen := g.Load()
+	assert.True(t, g.Validate(gen), "Generation of an unset GenerationalValue wasn't valid.")
+	assert.False(t, g.Validate(Generation{}), "zero Generation was valid.")
+
+	g.Store(struct{}{})
+	assert.False(t, g.Validate(gen), "Generation was valid after a Store.")
+
+	_, gen = g.Load()
+	assert.True(t, g.Validate(gen), "Generation wasn't valid without a Store.")
+	g.Store(struct{}{})
+	assert.False(t, g.Validate(gen), "Generation was valid after storing an equal value.")
+}
+
+func TestGenerationalValueConcurrentStore(t *testing.T) {
+	var g GenerationalValue[int]
+	g.Store(1)
+
+	read := func(during func()) (sum int, attempts int) {
+		for {
+			attempts++
+			val, gen := g.Load()
+			sum = val * 2
+			if attempts == 1 {
+				during()
+			}
+			if g.Validate(gen) {
+				return sum, attempts
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	sum, attempts := read(func() {
+		go func() {
+			g.Store(2)
+			close(done)
+		}()
+		<-done
+	})
+	assert.Equal(t, 2, attempts, "concurrent Store didn't invalidate the first read.")
+	assert.Equal(t, 4, sum, "retried read didn't observe the new value.")
+}
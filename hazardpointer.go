@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// HazardPointer is an atomic pointer to a T with safe reclamation of replaced pointers using hazard pointers. Readers
+// pin the current pointer using Protect, which guarantees that it is not freed until the HazardGuard returned is
+// released. Pointers replaced by Store are retired, and passed to the free function of the HazardPointer once no
+// HazardGuard protects them anymore. This allows lock-free data structures to recycle or release memory, such as
+// pooled or manually managed objects, without use-after-free.
+//
+// As Go has no goroutine-local storage, hazard slots are not tied to goroutines. Instead, each HazardGuard occupies
+// one slot until it is released, and slots are reused by later calls to Protect, so that the number of slots grows
+// to the maximum number of guards held concurrently, typically one per reader goroutine. HazardPointers must be
+// created using NewHazardPointer.
+type HazardPointer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	p    UnsafePointer
+	free func(*T)
+
+	// slots holds all hazard slots ever allocated. It only grows, and is replaced with a longer copy under mu.
+	slots Value[[]*hazardSlot]
+
+	mu      sync.Mutex
+	retired []*T
+}
+
+// hazardSlot holds a pointer protected by a HazardGuard.
+type hazardSlot struct {
+	p     UnsafePointer
+	inUse Bool
+}
+
+// HazardGuard protects a pointer loaded from a HazardPointer from being freed until it is released.
+type HazardGuard[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	p    *T
+	slot *hazardSlot
+}
+
+// NewHazardPointer creates a new HazardPointer holding val, which passes every retired pointer to free once it is no
+// longer protected. free is called with the lock of the HazardPointer held during Store, so it must not call methods
+// of the HazardPointer.
+func NewHazardPointer[T any](val *T, free func(*T)) *HazardPointer[T] {
+	h := &HazardPointer[T]{free: free}
+	h.p.Store(unsafe.Pointer(val))
+	return h
+}
+
+// Protect loads the current pointer and protects it from being freed until the HazardGuard returned is released by
+// calling its Release method. The guard must be released exactly once, and the pointer must not be used afterwards.
+func (h *HazardPointer[T]) Protect() *HazardGuard[T] {
+	slot := h.acquireSlot()
+	for {
+		p := h.p.Load()
+		slot.p.Store(p)
+		// The pointer is only safe to use if it was not replaced, and thus possibly retired and freed, before the slot
+		// was published.
+		if h.p.Load() == p {
+			return &HazardGuard[T]{p: (*T)(p), slot: slot}
+		}
+	}
+}
+
+// acquireSlot returns a hazard slot that is not in use, allocating a new one if all slots are in use.
+func (h *HazardPointer[T]) acquireSlot() *hazardSlot {
+	for _, slot := range h.slots.Load() {
+		if slot.inUse.CAS(false, true) {
+			return slot
+		}
+	}
+	slot := &hazardSlot{}
+	slot.inUse.Store(true)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	current := h.slots.Load()
+	slots := make([]*hazardSlot, len(current), len(current)+1)
+	copy(slots, current)
+	h.slots.Store(append(slots, slot))
+	return slot
+}
+
+// Pointer returns the pointer protected by the HazardGuard.
+func (g *HazardGuard[T]) Pointer() *T {
+	return g.p
+}
+
+// Release releases the protection of the pointer held by the HazardGuard, allowing it to be freed once it is
+// retired.
+func (g *HazardGuard[T]) Release() {
+	g.slot.p.Store(nil)
+	g.slot.inUse.Store(false)
+}
+
+// Store atomically stores val and retires the pointer previously held.
+func (h *HazardPointer[T]) Store(val *T) {
+	h.Retire((*T)(h.p.Swap(unsafe.Pointer(val))))
+}
+
+// Retire schedules old to be passed to the free function of the HazardPointer once no HazardGuard protects it. old
+// must no longer be reachable through the HazardPointer, so that no new guard can protect it. Retire frees all
+// previously retired pointers that are no longer protected, and keeps the others for the next call to Retire.
+func (h *HazardPointer[T]) Retire(old *T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if old != nil {
+		h.retired = append(h.retired, old)
+	}
+	protected := make(map[unsafe.Pointer]struct{})
+	for _, slot := range h.slots.Load() {
+		if p := slot.p.Load(); p != nil {
+			protected[p] = struct{}{}
+		}
+	}
+
+	kept := h.retired[:0]
+	for _, p := range h.retired {
+		if _, ok := protected[unsafe.Pointer(p)]; ok {
+			kept = append(kept, p)
+			continue
+		}
+		h.free(p)
+	}
+	for i := len(kept); i < len(h.retired); i++ {
+		h.retired[i] = nil
+	}
+	h.retired = kept
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hazardObject is an object managed through a HazardPointer in tests. freed is deliberately not atomic, so that the
+// race detector reports a use-after-free as a data race.
+type hazardObject struct {
+	val   int
+	freed bool
+}
+
+func TestHazardPointer(t *testing.T) {
+	var freed []*hazardObject
+	first := &hazardObject{val: 1}
+	h := NewHazardPointer(first, func(o *hazardObject) { freed = append(freed, o) })
+
+	g := h.Protect()
+	assert.True(t, g.Pointer() == first, "Protect didn't return the current pointer.")
+
+	h.Store(&hazardObject{val: 2})
+	assert.Empty(t, freed, "Store freed a protected pointer.")
+
+	g.Release()
+	h.Store(&hazardObject{val: 3})
+	assert.Len(t, freed, 2, "Store didn't free pointers no longer protected.")
+	assert.True(t, freed[0] == first, "Store didn't free the released pointer.")
+
+	g = h.Protect()
+	assert.Equal(t, 3, g.Pointer().val, "Protect didn't return the current pointer.")
+	g.Release()
+	assert.Len(t, h.slots.Load(), 1, "Protect didn't reuse a released hazard slot.")
+}
+
+func TestHazardPointerStress(t *testing.T) {
+	const (
+		readers = 4
+		stores  = 1000
+	)
+
+	var (
+		h = NewHazardPointer(&hazardObject{}, func(o *hazardObject) {
+			o.freed = true
+		})
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+	wg.Add(readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				g := h.Protect()
+				if o := g.Pointer(); o.freed {
+					assert.Fail(t, "protected pointer was freed.", "value %v", o.val)
+				}
+				g.Release()
+			}
+		}()
+	}
+	for i := 1; i <= stores; i++ {
+		h.Store(&hazardObject{val: i})
+	}
+	close(done)
+	wg.Wait()
+}
@@ -44,6 +44,9 @@ func TestInt32(t *testing.T) {
 	require.Equal(t, int32(0), atom.Swap(1), "Swap didn't return the old value.")
 	require.Equal(t, int32(1), atom.Load(), "Swap didn't set the correct value.")
 
+	require.Equal(t, int32(1), atom.SwapZero(), "SwapZero didn't return the old value.")
+	require.Equal(t, int32(0), atom.Load(), "SwapZero didn't reset the value.")
+
 	atom.Store(42)
 	require.Equal(t, int32(42), atom.Load(), "Store didn't set the correct value.")
 
@@ -80,3 +83,14 @@ func TestInt32(t *testing.T) {
 		})
 	})
 }
+
+func TestInt32Comparisons(t *testing.T) {
+	atom := NewInt32(5)
+	assert.True(t, atom.GreaterThan(-3), "GreaterThan of a smaller value returned false.")
+	assert.False(t, atom.GreaterThan(5), "GreaterThan of an equal value returned true.")
+	assert.True(t, atom.LessThan(6), "LessThan of a greater value returned false.")
+	assert.False(t, atom.LessThan(5), "LessThan of an equal value returned true.")
+	assert.True(t, atom.Between(5, 5), "Between of equal bounds returned false.")
+	assert.True(t, atom.Between(-3, 6), "Between of surrounding bounds returned false.")
+	assert.False(t, atom.Between(6, 8), "Between of greater bounds returned true.")
+}
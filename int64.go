@@ -1,6 +1,6 @@
 // @generated Code generated by gen-atomicint.
 
-// Copyright (c) 2020-2021 Uber Technologies, Inc.
+// Copyright (c) 2020-2026 Uber Technologies, Inc.
 //
 // Permission is hereby granted, free of charge, to any person obtaining a copy
 // of this software and associated documentation files (the "Software"), to deal
@@ -80,6 +80,69 @@ func (i *Int64) Swap(val int64) (old int64) {
 	return atomic.SwapInt64(&i.v, val)
 }
 
+// SwapZero atomically resets the wrapped int64 to zero and returns the
+// old value. It may be used to read and reset a counter at the end of an
+// interval without losing increments made concurrently.
+func (i *Int64) SwapZero() (old int64) {
+	return i.Swap(0)
+}
+
+// AddSaturating atomically adds delta to the wrapped int64, limiting the
+// result to max, and returns the new value. An addition that would exceed max or
+// overflow stores max instead, while an underflow caused by a negative
+// delta wraps around like Add. If the wrapped int64 is already
+// greater than max, AddSaturating stores max.
+func (i *Int64) AddSaturating(delta, max int64) int64 {
+	for {
+		old := i.Load()
+		new := old + delta
+		if new > max || (delta > 0 && new < old) {
+			new = max
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from the wrapped int64,
+// limiting the result to min, and returns the new value. A subtraction that
+// would go below min or underflow stores min instead, while an overflow caused
+// by a negative delta wraps around like Sub. If the wrapped int64 is
+// already less than min, SubSaturating stores min.
+func (i *Int64) SubSaturating(delta, min int64) int64 {
+	for {
+		old := i.Load()
+		new := old - delta
+		if new < min || (delta > 0 && new > old) {
+			new = min
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped int64 and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (i *Int64) GreaterThan(v int64) bool {
+	return i.Load() > v
+}
+
+// LessThan atomically loads the wrapped int64 and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (i *Int64) LessThan(v int64) bool {
+	return i.Load() < v
+}
+
+// Between atomically loads the wrapped int64 and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot.
+func (i *Int64) Between(lo, hi int64) bool {
+	v := i.Load()
+	return v >= lo && v <= hi
+}
+
 // MarshalJSON encodes the wrapped int64 into JSON.
 func (i *Int64) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Load())
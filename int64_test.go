@@ -23,6 +23,7 @@ package atomic
 import (
 	"encoding/json"
 	"math"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,6 +45,9 @@ func TestInt64(t *testing.T) {
 	require.Equal(t, int64(0), atom.Swap(1), "Swap didn't return the old value.")
 	require.Equal(t, int64(1), atom.Load(), "Swap didn't set the correct value.")
 
+	require.Equal(t, int64(1), atom.SwapZero(), "SwapZero didn't return the old value.")
+	require.Equal(t, int64(0), atom.Load(), "SwapZero didn't reset the value.")
+
 	atom.Store(42)
 	require.Equal(t, int64(42), atom.Load(), "Store didn't set the correct value.")
 
@@ -80,3 +84,87 @@ func TestInt64(t *testing.T) {
 		})
 	})
 }
+
+func TestInt64SwapZeroConcurrent(t *testing.T) {
+	const (
+		incrementers = 4
+		increments   = 1000
+	)
+
+	var (
+		atom  Int64
+		wg    sync.WaitGroup
+		total int64
+		done  = make(chan struct{})
+	)
+
+	wg.Add(incrementers)
+	for i := 0; i < incrementers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				atom.Inc()
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			total += atom.SwapZero()
+			assert.Equal(t, int64(incrementers*increments), total,
+				"SwapZero lost increments made concurrently.")
+			return
+		default:
+			total += atom.SwapZero()
+		}
+	}
+}
+
+func TestInt64Saturating(t *testing.T) {
+	atom := NewInt64(8)
+	require.Equal(t, int64(9), atom.AddSaturating(1, 10), "AddSaturating below max didn't add.")
+	require.Equal(t, int64(10), atom.AddSaturating(1, 10), "AddSaturating up to max didn't add.")
+	require.Equal(t, int64(10), atom.AddSaturating(1, 10), "AddSaturating at max didn't saturate.")
+	require.Equal(t, int64(12), atom.AddSaturating(5, 12), "AddSaturating beyond max didn't saturate.")
+
+	require.Equal(t, int64(-1), atom.SubSaturating(13, -5), "SubSaturating above min didn't subtract.")
+	require.Equal(t, int64(-5), atom.SubSaturating(10, -5), "SubSaturating beyond min didn't saturate.")
+
+	atom.Store(math.MaxInt64 - 1)
+	require.Equal(t, int64(math.MaxInt64), atom.AddSaturating(10, math.MaxInt64),
+		"AddSaturating didn't saturate on overflow.")
+	atom.Store(math.MinInt64 + 1)
+	require.Equal(t, int64(math.MinInt64), atom.SubSaturating(10, math.MinInt64),
+		"SubSaturating didn't saturate on underflow.")
+}
+
+func TestInt64AddSaturatingConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		increments = 1000
+		max        = goroutines * increments / 2
+	)
+
+	var (
+		atom Int64
+		wg   sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				if v := atom.AddSaturating(1, max); v > max {
+					assert.Fail(t, "AddSaturating exceeded max.", "got %v", v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(max), atom.Load(), "concurrent AddSaturating didn't saturate at max.")
+}
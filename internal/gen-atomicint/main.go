@@ -194,6 +194,69 @@ func (i *{{ .Name }}) Swap(val {{ .Wrapped }}) (old {{ .Wrapped }}) {
 	return atomic.Swap{{ .Name }}(&i.v, val)
 }
 
+// SwapZero atomically resets the wrapped {{ .Wrapped }} to zero and returns the
+// old value. It may be used to read and reset a counter at the end of an
+// interval without losing increments made concurrently.
+func (i *{{ .Name }}) SwapZero() (old {{ .Wrapped }}) {
+	return i.Swap(0)
+}
+
+// AddSaturating atomically adds delta to the wrapped {{ .Wrapped }}, limiting the
+// result to max, and returns the new value. An addition that would exceed max or
+// overflow stores max instead{{ if not .Unsigned }}, while an underflow caused by a negative
+// delta wraps around like Add{{ end }}. If the wrapped {{ .Wrapped }} is already
+// greater than max, AddSaturating stores max.
+func (i *{{ .Name }}) AddSaturating(delta, max {{ .Wrapped }}) {{ .Wrapped }} {
+	for {
+		old := i.Load()
+		new := old + delta
+		if new > max || {{ if .Unsigned }}new < old{{ else }}(delta > 0 && new < old){{ end }} {
+			new = max
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from the wrapped {{ .Wrapped }},
+// limiting the result to min, and returns the new value. A subtraction that
+// would go below min or underflow stores min instead{{ if not .Unsigned }}, while an overflow caused
+// by a negative delta wraps around like Sub{{ end }}. If the wrapped {{ .Wrapped }} is
+// already less than min, SubSaturating stores min.
+func (i *{{ .Name }}) SubSaturating(delta, min {{ .Wrapped }}) {{ .Wrapped }} {
+	for {
+		old := i.Load()
+		new := old - delta
+		if new < min || {{ if .Unsigned }}new > old{{ else }}(delta > 0 && new > old){{ end }} {
+			new = min
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped {{ .Wrapped }} and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (i *{{ .Name }}) GreaterThan(v {{ .Wrapped }}) bool {
+	return i.Load() > v
+}
+
+// LessThan atomically loads the wrapped {{ .Wrapped }} and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (i *{{ .Name }}) LessThan(v {{ .Wrapped }}) bool {
+	return i.Load() < v
+}
+
+// Between atomically loads the wrapped {{ .Wrapped }} and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot.
+func (i *{{ .Name }}) Between(lo, hi {{ .Wrapped }}) bool {
+	v := i.Load()
+	return v >= lo && v <= hi
+}
+
 // MarshalJSON encodes the wrapped {{ .Wrapped }} into JSON.
 func (i *{{ .Name }}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Load())
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// InternedMap is a map of values of type V keyed by strings, whose keys are interned: all keys with equal contents
+// stored to the InternedMap share a single backing string, no matter how many times they are stored or whether they
+// were passed as string or []byte. This saves memory for configuration maps that are rebuilt repeatedly from the
+// same keys. InternedMap is backed by a copy-on-write map: Load and Range are lock-free, and writes atomically
+// publish a modified copy of the map, retrying if another writer published one first. The zero value is an empty
+// InternedMap ready to use.
+type InternedMap[V any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	m Value[*map[string]V]
+	// interned maps every key ever stored to its interned copy. Keys are never removed from it, so that a key that is
+	// deleted and stored again keeps its interned copy.
+	interned sync.Map
+}
+
+// intern returns the interned copy of key, interning key if it was not stored before.
+func (m *InternedMap[V]) intern(key string) string {
+	if s, ok := m.interned.Load(key); ok {
+		return s.(string)
+	}
+	// Copy key so that the interned copy does not keep a larger string or mutable byte slice it was taken from alive.
+	s, _ := m.interned.LoadOrStore(key, string(append([]byte(nil), key...)))
+	return s.(string)
+}
+
+// load returns the map currently held by the InternedMap, which must not be modified.
+func (m *InternedMap[V]) load() map[string]V {
+	if mp := m.m.Load(); mp != nil {
+		return *mp
+	}
+	return nil
+}
+
+// Load returns the value stored for key and reports whether key was present.
+func (m *InternedMap[V]) Load(key string) (val V, ok bool) {
+	val, ok = m.load()[key]
+	return val, ok
+}
+
+// LoadBytes returns the value stored for the key with the contents of key and reports whether it was present.
+func (m *InternedMap[V]) LoadBytes(key []byte) (val V, ok bool) {
+	val, ok = m.load()[string(key)]
+	return val, ok
+}
+
+// Store atomically stores val for the interned copy of key.
+func (m *InternedMap[V]) Store(key string, val V) {
+	m.store(m.intern(key), val)
+}
+
+// StoreBytes atomically stores val for the interned copy of the contents of key. key may be modified after
+// StoreBytes returns.
+func (m *InternedMap[V]) StoreBytes(key []byte, val V) {
+	m.store(m.intern(string(key)), val)
+}
+
+// store atomically stores val for key, which must already be interned.
+func (m *InternedMap[V]) store(key string, val V) {
+	m.m.update(func(old *map[string]V) (*map[string]V, bool) {
+		mp := copyInternedMap(old, 1)
+		mp[key] = val
+		return &mp, true
+	})
+}
+
+// Delete atomically removes key from the InternedMap and reports whether it was present.
+func (m *InternedMap[V]) Delete(key string) (deleted bool) {
+	_, deleted = m.m.update(func(old *map[string]V) (*map[string]V, bool) {
+		if old == nil {
+			return nil, false
+		}
+		if _, ok := (*old)[key]; !ok {
+			return nil, false
+		}
+		mp := copyInternedMap(old, 0)
+		delete(mp, key)
+		return &mp, true
+	})
+	return deleted
+}
+
+// copyInternedMap copies the map m points to, which may be nil, with room for extra additional values.
+func copyInternedMap[V any](m *map[string]V, extra int) map[string]V {
+	if m == nil {
+		return make(map[string]V, extra)
+	}
+	cp := make(map[string]V, len(*m)+extra)
+	for k, v := range *m {
+		cp[k] = v
+	}
+	return cp
+}
+
+// Len returns the number of keys in the InternedMap.
+func (m *InternedMap[V]) Len() int {
+	return len(m.load())
+}
+
+// Range calls f with every key in the InternedMap, in no particular order, and the value stored for it. The keys
+// passed are the interned copies. Range stops if f returns false. Range iterates over a consistent snapshot of the
+// InternedMap taken at the start of the call.
+func (m *InternedMap[V]) Range(f func(key string, val V) bool) {
+	for k, v := range m.load() {
+		if !f(k, v) {
+			return
+		}
+	}
+}
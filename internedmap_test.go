@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stringData returns a pointer to the backing array of s.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestInternedMap(t *testing.T) {
+	var m InternedMap[int]
+	_, ok := m.Load("foo")
+	require.False(t, ok, "Load of an empty InternedMap found a key.")
+
+	m.Store("foo", 1)
+	m.StoreBytes([]byte("bar"), 2)
+	val, ok := m.Load("foo")
+	require.True(t, ok, "Load didn't find a stored key.")
+	require.Equal(t, 1, val, "Load returned the wrong value.")
+	val, ok = m.LoadBytes([]byte("bar"))
+	require.True(t, ok, "LoadBytes didn't find a stored key.")
+	require.Equal(t, 2, val, "LoadBytes returned the wrong value.")
+	require.Equal(t, 2, m.Len(), "Len returned the wrong number of keys.")
+
+	require.True(t, m.Delete("foo"), "Delete didn't report a present key as deleted.")
+	require.False(t, m.Delete("foo"), "Delete reported a missing key as deleted.")
+	_, ok = m.Load("foo")
+	require.False(t, ok, "Load found a deleted key.")
+}
+
+func TestInternedMapInterning(t *testing.T) {
+	var m InternedMap[int]
+	b := []byte("key")
+	m.StoreBytes(b, 1)
+	b[0] = 'x'
+	m.Store(string([]byte("key")), 2)
+	m.Store(string([]byte("key")), 3)
+
+	var keys []string
+	m.Range(func(key string, val int) bool {
+		keys = append(keys, key)
+		assert.Equal(t, 3, val, "Range passed the wrong value.")
+		return true
+	})
+	require.Equal(t, []string{"key"}, keys, "modifying a key passed to StoreBytes modified the InternedMap.")
+
+	first, second := m.intern(string([]byte("key"))), m.intern(string([]byte("key")))
+	assert.Equal(t, stringData(keys[0]), stringData(first), "equal keys weren't interned to the same string.")
+	assert.Equal(t, stringData(first), stringData(second), "equal keys weren't interned to the same string.")
+}
+
+func TestInternedMapConcurrent(t *testing.T) {
+	const goroutines = 8
+
+	var (
+		m  InternedMap[int]
+		wg sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			m.Store(string([]byte("shared")), i)
+			m.Store(string(rune('a'+i)), i)
+			m.Load("shared")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, goroutines+1, m.Len(), "keys were lost under concurrent stores.")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "io"
+
+// LastWrite is an io.Writer that atomically records the bytes passed to the most recent call to Write, so that the
+// latest output of a writer may be read lock-free using Load. LastWrite keeps only the last write and does not
+// concatenate the bytes of multiple writes. The zero value is ready to use.
+type LastWrite struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[[]byte]
+}
+
+var _ io.Writer = (*LastWrite)(nil)
+
+// Write copies p and atomically stores the copy, replacing the bytes of any previous write. Write always returns
+// len(p) and a nil error.
+func (w *LastWrite) Write(p []byte) (n int, err error) {
+	w.v.Store(append([]byte(nil), p...))
+	return len(p), nil
+}
+
+// Load returns a copy of the bytes passed to the most recent call to Write, or nil if Write was never called.
+func (w *LastWrite) Load() []byte {
+	b := w.v.Load()
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
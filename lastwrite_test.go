@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastWrite(t *testing.T) {
+	var w LastWrite
+	assert.Nil(t, w.Load(), "Load of an unwritten LastWrite didn't return nil.")
+
+	p := []byte("foo")
+	n, err := w.Write(p)
+	assert.NoError(t, err, "Write returned an error.")
+	assert.Equal(t, 3, n, "Write didn't return the length of p.")
+
+	p[0] = 'b'
+	assert.Equal(t, []byte("foo"), w.Load(), "LastWrite didn't copy the bytes written.")
+
+	b := w.Load()
+	b[0] = 'z'
+	assert.Equal(t, []byte("foo"), w.Load(), "Load didn't return a copy.")
+
+	_, _ = fmt.Fprint(&w, "bar")
+	assert.Equal(t, []byte("bar"), w.Load(), "LastWrite didn't keep only the last write.")
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Latch is a flag that may be tripped once and stays tripped until it is explicitly reset, for example to model the
+// open state of a circuit breaker. Tripping and checking the Latch is lock-free. The zero value is an armed Latch.
+type Latch struct {
+	_ nocmp // disallow non-atomic comparison
+
+	tripped Bool
+}
+
+// Trip trips the Latch and reports whether this call tripped it. Out of any number of concurrent calls to Trip on an
+// armed Latch, exactly one returns true. Tripping a Latch that is already tripped has no effect.
+func (l *Latch) Trip() (tripped bool) {
+	return l.tripped.CAS(false, true)
+}
+
+// IsTripped reports whether the Latch is currently tripped.
+func (l *Latch) IsTripped() bool {
+	return l.tripped.Load()
+}
+
+// Reset re-arms the Latch, so that the next call to Trip trips it again. Reset is meant for explicit administrative
+// action: no other method of Latch resets it.
+func (l *Latch) Reset() {
+	l.tripped.Store(false)
+}
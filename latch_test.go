@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatch(t *testing.T) {
+	var l Latch
+	assert.False(t, l.IsTripped(), "zero Latch was tripped.")
+	assert.True(t, l.Trip(), "Trip of an armed Latch didn't report tripping it.")
+	assert.False(t, l.Trip(), "Trip of a tripped Latch reported tripping it.")
+	assert.True(t, l.IsTripped(), "Trip didn't trip the Latch.")
+
+	l.Reset()
+	assert.False(t, l.IsTripped(), "Reset didn't re-arm the Latch.")
+	assert.True(t, l.Trip(), "Trip after Reset didn't report tripping the Latch.")
+}
+
+func TestLatchConcurrentTrip(t *testing.T) {
+	const (
+		goroutines = 8
+		cycles     = 100
+	)
+
+	var l Latch
+	for i := 0; i < cycles; i++ {
+		var (
+			wg      sync.WaitGroup
+			tripped Int32
+		)
+		wg.Add(goroutines)
+		for j := 0; j < goroutines; j++ {
+			go func() {
+				defer wg.Done()
+				if l.Trip() {
+					tripped.Inc()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), tripped.Load(), "not exactly one Trip tripped the Latch in cycle %v.", i)
+		l.Reset()
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Linked holds a value of type T together with a value of type S derived from it. Both values are published through
+// a single atomic store, so that readers never observe a T with an S derived from a different T.
+type Linked[T, S any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[linkedPair[T, S]]
+}
+
+// linkedPair is a T and the S derived from it, as held by a Linked[T, S].
+type linkedPair[T, S any] struct {
+	t T
+	s S
+}
+
+// Store atomically stores t together with the result of derive(t).
+func (l *Linked[T, S]) Store(t T, derive func(T) S) {
+	l.v.Store(linkedPair[T, S]{t: t, s: derive(t)})
+}
+
+// Load atomically loads the T and the S derived from it. Load returns the zero values of T and S if nothing was
+// stored yet.
+func (l *Linked[T, S]) Load() (T, S) {
+	p := l.v.Load()
+	return p.t, p.s
+}
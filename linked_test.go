@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinked(t *testing.T) {
+	var l Linked[int, string]
+	v, s := l.Load()
+	assert.Zero(t, v, "Load of an empty Linked didn't return a zero T.")
+	assert.Zero(t, s, "Load of an empty Linked didn't return a zero S.")
+
+	l.Store(42, strconv.Itoa)
+	v, s = l.Load()
+	assert.Equal(t, 42, v, "Load didn't return the stored T.")
+	assert.Equal(t, "42", s, "Load didn't return the derived S.")
+}
+
+func TestLinkedConsistent(t *testing.T) {
+	const (
+		readers = 4
+		writes  = 1000
+	)
+
+	var (
+		l      Linked[int, int]
+		double = func(v int) int { return v * 2 }
+		wg     sync.WaitGroup
+		done   = make(chan struct{})
+	)
+	wg.Add(readers)
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if v, s := l.Load(); s != double(v) {
+					t.Errorf("Load returned mismatched pair (%v, %v)", v, s)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < writes; i++ {
+		l.Store(i, double)
+	}
+	close(done)
+	wg.Wait()
+}
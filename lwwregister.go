@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// LWWRegister is a last-writer-wins register, a CRDT holding a value of type T along with the logical clock and node
+// ID of the write that set it. A write only takes effect if its clock is greater than the one held, with ties broken
+// by the greater node ID, so that registers receiving the same writes in any order converge to the same value. The
+// zero value holds the zero value of T at clock 0 and node ID 0, and accepts any write with a clock or node ID
+// greater than 0.
+type LWWRegister[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*lwwEntry[T]]
+}
+
+// lwwEntry holds a value written to an LWWRegister along with the clock and node ID of the write.
+type lwwEntry[T any] struct {
+	val           T
+	clock, nodeID uint64
+}
+
+// newerThan checks if the write of e supersedes a write with the clock and node ID of other.
+func (e *lwwEntry[T]) newerThan(other *lwwEntry[T]) bool {
+	if other == nil {
+		return e.clock > 0 || e.nodeID > 0
+	}
+	if e.clock != other.clock {
+		return e.clock > other.clock
+	}
+	return e.nodeID > other.nodeID
+}
+
+// Set atomically stores val if the pair of clock and nodeID is greater than the one of the value currently held, and
+// reports whether it did so. Pairs are ordered by clock first and by nodeID second.
+func (r *LWWRegister[T]) Set(val T, clock, nodeID uint64) (won bool) {
+	e := &lwwEntry[T]{val: val, clock: clock, nodeID: nodeID}
+	_, won = r.v.update(func(old *lwwEntry[T]) (*lwwEntry[T], bool) {
+		return e, e.newerThan(old)
+	})
+	return won
+}
+
+// Load atomically loads the value currently held.
+func (r *LWWRegister[T]) Load() (val T) {
+	if e := r.v.Load(); e != nil {
+		return e.val
+	}
+	return val
+}
+
+// Clock atomically loads the clock and node ID of the write that set the value currently held.
+func (r *LWWRegister[T]) Clock() (clock, nodeID uint64) {
+	if e := r.v.Load(); e != nil {
+		return e.clock, e.nodeID
+	}
+	return 0, 0
+}
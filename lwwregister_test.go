@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLWWRegister(t *testing.T) {
+	var r LWWRegister[string]
+	assert.False(t, r.Set("zero", 0, 0), "Set with clock and node ID 0 won against the zero value.")
+	assert.True(t, r.Set("a", 2, 1), "Set with a greater clock didn't win.")
+	assert.False(t, r.Set("b", 1, 5), "Set with a smaller clock won.")
+	assert.False(t, r.Set("c", 2, 1), "Set with an equal clock and node ID won.")
+	assert.True(t, r.Set("d", 2, 3), "Set with an equal clock and greater node ID didn't win.")
+	assert.False(t, r.Set("e", 2, 2), "Set with an equal clock and smaller node ID won.")
+
+	assert.Equal(t, "d", r.Load(), "Load didn't return the winning value.")
+	clock, nodeID := r.Clock()
+	assert.Equal(t, [2]uint64{2, 3}, [2]uint64{clock, nodeID}, "Clock didn't return the winning clock and node ID.")
+}
+
+func TestLWWRegisterConcurrentSets(t *testing.T) {
+	const nodes = 8
+
+	var (
+		r  LWWRegister[uint64]
+		wg sync.WaitGroup
+	)
+	wg.Add(nodes)
+	for node := uint64(1); node <= nodes; node++ {
+		node := node
+		go func() {
+			defer wg.Done()
+			for clock := uint64(1); clock <= 100; clock++ {
+				r.Set(node*1000+clock, clock, node)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(nodes*1000+100), r.Load(), "concurrent Sets didn't converge to the winning writer.")
+}
@@ -54,14 +54,60 @@ func TestNocmpComparability(t *testing.T) {
 		},
 
 		// All exported types must be uncomparable.
+		{desc: "Accumulator", give: Accumulator[int]{}},
+		{desc: "AtomicMap", give: AtomicMap[int, int]{}},
+		{desc: "AuditedValue", give: AuditedValue[int]{}},
+		{desc: "BigInt", give: BigInt{}},
 		{desc: "Bool", give: Bool{}},
+		{desc: "Bytes", give: Bytes{}},
+		{desc: "COWList", give: COWList[int]{}},
+		{desc: "CloneValue", give: CloneValue[cloneableSlice]{}},
+		{desc: "CoalescingValue", give: CoalescingValue[int]{}},
+		{desc: "Complex128", give: Complex128{}},
+		{desc: "Complex64", give: Complex64{}},
+		{desc: "CondValue", give: CondValue[int]{}},
+		{desc: "Counter", give: Counter{}},
+		{desc: "CounterMap", give: CounterMap[int]{}},
+		{desc: "Deque", give: Deque[int]{}},
+		{desc: "DoubleBuffer", give: DoubleBuffer[int]{}},
 		{desc: "Duration", give: Duration{}},
+		{desc: "EWMA", give: EWMA{}},
+		{desc: "ExpiringValue", give: ExpiringValue[int]{}},
+		{desc: "FlipFlop", give: FlipFlop[int]{}},
 		{desc: "Float64", give: Float64{}},
+		{desc: "Future", give: Future[int]{}},
+		{desc: "Gate", give: Gate{}},
+		{desc: "GatedValue", give: GatedValue[int]{}},
+		{desc: "GenerationalValue", give: GenerationalValue[int]{}},
+		{desc: "HazardGuard", give: HazardGuard[int]{}},
+		{desc: "HazardPointer", give: HazardPointer[int]{}},
 		{desc: "Int32", give: Int32{}},
 		{desc: "Int64", give: Int64{}},
+		{desc: "InternedMap", give: InternedMap[int]{}},
+		{desc: "LWWRegister", give: LWWRegister[int]{}},
+		{desc: "LastWrite", give: LastWrite{}},
+		{desc: "Latch", give: Latch{}},
+		{desc: "Linked", give: Linked[int, int]{}},
+		{desc: "PipelineValue", give: PipelineValue[int]{}},
+		{desc: "PointerIdentityValue", give: PointerIdentityValue[int]{}},
+		{desc: "PooledPointer", give: PooledPointer[int]{}},
+		{desc: "PriorityValue", give: PriorityValue[int]{}},
+		{desc: "ProtoValue", give: ProtoValue[int]{}},
+		{desc: "RateCounter", give: RateCounter{}},
+		{desc: "RoundRobin", give: RoundRobin[int]{}},
+		{desc: "Semaphore", give: Semaphore{}},
+		{desc: "Set", give: Set[int]{}},
+		{desc: "ShardedCounter", give: ShardedCounter{}},
+		{desc: "Single", give: Single[int]{}},
+		{desc: "StateMachine", give: StateMachine[int]{}},
+		{desc: "StickyValue", give: StickyValue[int]{}},
+		{desc: "Stopwatch", give: Stopwatch{}},
+		{desc: "TokenBucket", give: TokenBucket{}},
 		{desc: "Uint32", give: Uint32{}},
 		{desc: "Uint64", give: Uint64{}},
 		{desc: "Value", give: Value[any]{}},
+		{desc: "VersionedStore", give: VersionedStore[int]{}},
+		{desc: "WriteThrough", give: WriteThrough[int]{}},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// PipelineValue is a value of type T that normalises and validates every value before storing it. Each value stored
+// is passed through a chain of transforms and then a validator, and only published if the validator accepts it, so
+// that readers never observe a value that was not sanitised. Loading the value is lock-free. PipelineValues must be
+// created using NewPipelineValue.
+type PipelineValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v          Value[T]
+	transforms []func(T) T
+	validate   func(T) error
+}
+
+// NewPipelineValue creates a new, unset PipelineValue that passes values stored through transforms, in order, and
+// then validates the result using validate. validate may be nil to accept all values.
+func NewPipelineValue[T any](transforms []func(T) T, validate func(T) error) *PipelineValue[T] {
+	return &PipelineValue[T]{transforms: append([]func(T) T(nil), transforms...), validate: validate}
+}
+
+// Load atomically loads the value held, or the zero value of T if no value was stored yet.
+func (p *PipelineValue[T]) Load() T {
+	return p.v.Load()
+}
+
+// Store applies the transforms of the PipelineValue to val in order, validates the result and, if it is valid,
+// atomically stores it. If validation fails, Store returns the error of the validator and leaves the value held
+// unchanged. The transforms and validator are called by the goroutine calling Store, outside of any lock, so
+// concurrent calls to Store may run them concurrently.
+func (p *PipelineValue[T]) Store(val T) error {
+	for _, transform := range p.transforms {
+		val = transform(val)
+	}
+	if p.validate != nil {
+		if err := p.validate(val); err != nil {
+			return err
+		}
+	}
+	p.v.Store(val)
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineValue(t *testing.T) {
+	errEmpty := errors.New("empty name")
+	p := NewPipelineValue([]func(string) string{strings.TrimSpace, strings.ToLower}, func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	})
+
+	assert.NoError(t, p.Store("  Foo "), "Store of a valid value failed.")
+	assert.Equal(t, "foo", p.Load(), "Store didn't apply the transforms in order.")
+
+	assert.Equal(t, errEmpty, p.Store("   "), "Store didn't return the validator error.")
+	assert.Equal(t, "foo", p.Load(), "Store of an invalid value modified the value.")
+
+	var order []int
+	p2 := NewPipelineValue([]func(int) int{
+		func(v int) int { order = append(order, 1); return v + 1 },
+		func(v int) int { order = append(order, 2); return v * 10 },
+	}, nil)
+	assert.NoError(t, p2.Store(1), "Store without a validator failed.")
+	assert.Equal(t, 20, p2.Load(), "Store didn't apply the transforms in order.")
+	assert.Equal(t, []int{1, 2}, order, "Store didn't apply the transforms in order.")
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// PointerIdentityValue is a value of type T that is held by pointer, so that CompareAndSwap compares the pointers
+// held rather than the values they point to. This makes compare-and-swap usable for any T, including types that
+// are not comparable because they hold slices or maps, and for which CompareAndSwap of Value panics. Values must be
+// treated as immutable once stored: to modify one, Load the pointer, copy the value it points to, modify the copy
+// and CompareAndSwap the pointer loaded for a pointer to the copy, retrying if the swap fails. The zero value is an
+// unset PointerIdentityValue.
+type PointerIdentityValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v Value[*T]
+}
+
+// NewPointerIdentityValue creates a new PointerIdentityValue holding a pointer to a copy of val.
+func NewPointerIdentityValue[T any](val T) *PointerIdentityValue[T] {
+	p := &PointerIdentityValue[T]{}
+	p.Store(val)
+	return p
+}
+
+// Load atomically loads the pointer held, or nil if no value was stored yet. The value pointed to must not be
+// modified.
+func (p *PointerIdentityValue[T]) Load() *T {
+	return p.v.Load()
+}
+
+// LoadValue atomically loads the pointer held and returns the value it points to, or the zero value of T if no value
+// was stored yet. The copy returned is shallow.
+func (p *PointerIdentityValue[T]) LoadValue() (val T) {
+	if ptr := p.v.Load(); ptr != nil {
+		return *ptr
+	}
+	return val
+}
+
+// Store atomically stores a pointer to a copy of val.
+func (p *PointerIdentityValue[T]) Store(val T) {
+	p.v.Store(&val)
+}
+
+// CompareAndSwap atomically stores new if the pointer held is identical to old, and reports whether it did. old may
+// be nil to swap only if no value was stored yet. Unlike CompareAndSwap of Value, CompareAndSwap never panics, as
+// the values pointed to are never compared.
+func (p *PointerIdentityValue[T]) CompareAndSwap(old, new *T) (swapped bool) {
+	_, swapped = p.v.update(func(current *T) (*T, bool) {
+		return new, current == old
+	})
+	return swapped
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pointerIdentityConfig struct {
+	name  string
+	attrs map[string]int
+}
+
+func TestPointerIdentityValue(t *testing.T) {
+	var p PointerIdentityValue[pointerIdentityConfig]
+	assert.Nil(t, p.Load(), "Load of an unset PointerIdentityValue wasn't nil.")
+	assert.Equal(t, pointerIdentityConfig{}, p.LoadValue(), "LoadValue of an unset PointerIdentityValue wasn't the zero value.")
+
+	first := &pointerIdentityConfig{name: "first", attrs: map[string]int{"a": 1}}
+	assert.True(t, p.CompareAndSwap(nil, first), "CompareAndSwap of nil into an unset PointerIdentityValue failed.")
+	assert.True(t, p.Load() == first, "CompareAndSwap didn't store the new pointer.")
+
+	equal := &pointerIdentityConfig{name: "first", attrs: first.attrs}
+	second := &pointerIdentityConfig{name: "second", attrs: map[string]int{"b": 2}}
+	assert.False(t, p.CompareAndSwap(equal, second), "CompareAndSwap of an equal but distinct pointer swapped.")
+	assert.False(t, p.CompareAndSwap(nil, second), "CompareAndSwap of nil into a set PointerIdentityValue swapped.")
+	assert.True(t, p.CompareAndSwap(first, second), "CompareAndSwap of the pointer held didn't swap.")
+	assert.Equal(t, "second", p.LoadValue().name, "CompareAndSwap didn't store the new pointer.")
+
+	p.Store(pointerIdentityConfig{name: "third"})
+	assert.Equal(t, "third", p.LoadValue().name, "Store didn't store the value.")
+	assert.Equal(t, "fourth", NewPointerIdentityValue(pointerIdentityConfig{name: "fourth"}).LoadValue().name,
+		"NewPointerIdentityValue didn't store the value.")
+}
+
+func TestPointerIdentityValueConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		updates    = 100
+	)
+
+	p := NewPointerIdentityValue(pointerIdentityConfig{attrs: map[string]int{}})
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < updates; j++ {
+				for {
+					old := p.Load()
+					attrs := make(map[string]int, len(old.attrs))
+					for k, v := range old.attrs {
+						attrs[k] = v
+					}
+					attrs["n"]++
+					if p.CompareAndSwap(old, &pointerIdentityConfig{attrs: attrs}) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, goroutines*updates, p.LoadValue().attrs["n"], "updates were lost under concurrent compare-and-swaps.")
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// PooledPointer is an atomic pointer to a value of type T that returns every pointer it replaces to a sync.Pool, so
+// that large values published through it may be reused instead of garbage collected. PooledPointers must be created
+// using NewPooledPointer.
+//
+// Pooling a replaced pointer is only safe if no reader still uses it: a reader that loaded the pointer before it was
+// replaced may otherwise observe it being reset or reused by another goroutine. PooledPointer should therefore only
+// be used if readers are known to be done with a value once it is replaced, for example through external quiescence
+// such as an epoch or a barrier between updates.
+type PooledPointer[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	pool  *sync.Pool
+	reset func(*T)
+	v     Value[*T]
+}
+
+// NewPooledPointer creates a new PooledPointer returning replaced pointers to pool. If reset is not nil, it is called
+// with every replaced pointer before it is put into the pool. pool.New, if set, must return a *T.
+func NewPooledPointer[T any](pool *sync.Pool, reset func(*T)) *PooledPointer[T] {
+	return &PooledPointer[T]{pool: pool, reset: reset}
+}
+
+// Get returns a *T from the pool of the PooledPointer, which may be filled and passed to Store. Get returns a newly
+// allocated T if the pool is empty and has no New function.
+func (p *PooledPointer[T]) Get() *T {
+	if val, ok := p.pool.Get().(*T); ok {
+		return val
+	}
+	return new(T)
+}
+
+// Load atomically loads the pointer held, or nil if none was stored yet.
+func (p *PooledPointer[T]) Load() *T {
+	return p.v.Load()
+}
+
+// Store atomically stores val and returns the pointer previously held, if any, to the pool, after passing it to the
+// reset function of the PooledPointer. See PooledPointer for when this is safe.
+func (p *PooledPointer[T]) Store(val *T) {
+	old := p.v.Swap(val)
+	if old == nil || old == val {
+		return
+	}
+	if p.reset != nil {
+		p.reset(old)
+	}
+	p.pool.Put(old)
+}
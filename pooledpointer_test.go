@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPooledPointer(t *testing.T) {
+	type buffer struct{ data []byte }
+
+	var (
+		resets int
+		pool   = &sync.Pool{}
+		p      = NewPooledPointer(pool, func(b *buffer) {
+			resets++
+			b.data = b.data[:0]
+		})
+	)
+	assert.Nil(t, p.Load(), "new PooledPointer didn't hold nil.")
+
+	first := p.Get()
+	first.data = append(first.data, "foo"...)
+	p.Store(first)
+	assert.True(t, p.Load() == first, "Load didn't return the pointer stored.")
+	assert.Equal(t, 0, resets, "Store of the first pointer called reset.")
+
+	p.Store(first)
+	assert.Equal(t, 0, resets, "Store of the pointer already held called reset.")
+
+	p.Store(&buffer{data: []byte("bar")})
+	assert.Equal(t, 1, resets, "Store didn't reset the replaced pointer.")
+	assert.Empty(t, first.data, "reset func wasn't called with the replaced pointer.")
+	assert.Equal(t, "bar", string(p.Load().data), "Store didn't store the new pointer.")
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// PriorityValue is a min-heap of values of type T ordered by a key function. The minimum value may be read lock-free
+// using Min, which makes PriorityValue suited for loops that frequently peek the item with the highest priority.
+// Push and Pop are serialised by a mutex, so PriorityValue is not a lock-free heap: only reading the minimum avoids
+// locking.
+type PriorityValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	mu  sync.Mutex
+	h   priorityHeap[T]
+	min Value[*T]
+}
+
+// NewPriorityValue creates a new, empty PriorityValue ordering values by key. The value for which key returns the
+// lowest number is the minimum.
+func NewPriorityValue[T any](key func(T) int64) *PriorityValue[T] {
+	return &PriorityValue[T]{h: priorityHeap[T]{key: key}}
+}
+
+// Min returns the minimum value held by the PriorityValue without locking. It returns false if the PriorityValue is
+// empty.
+func (p *PriorityValue[T]) Min() (val T, ok bool) {
+	if min := p.min.Load(); min != nil {
+		return *min, true
+	}
+	return val, false
+}
+
+// Push adds val to the PriorityValue. Push blocks while another Push or Pop is in progress.
+func (p *PriorityValue[T]) Push(val T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	heap.Push(&p.h, val)
+	p.publish()
+}
+
+// Pop removes the minimum value from the PriorityValue and returns it. It returns false if the PriorityValue is
+// empty. Pop blocks while another Push or Pop is in progress.
+func (p *PriorityValue[T]) Pop() (val T, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.h.Len() == 0 {
+		return val, false
+	}
+	val = heap.Pop(&p.h).(T)
+	p.publish()
+	return val, true
+}
+
+// publish stores the current minimum of the heap so that it may be read by Min. publish must be called with p.mu
+// held.
+func (p *PriorityValue[T]) publish() {
+	if p.h.Len() == 0 {
+		p.min.Store(nil)
+		return
+	}
+	min := p.h.vals[0]
+	p.min.Store(&min)
+}
+
+// priorityHeap implements heap.Interface for values of type T ordered by key.
+type priorityHeap[T any] struct {
+	vals []T
+	key  func(T) int64
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.vals) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.key(h.vals[i]) < h.key(h.vals[j]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.vals[i], h.vals[j] = h.vals[j], h.vals[i] }
+func (h *priorityHeap[T]) Push(x any)         { h.vals = append(h.vals, x.(T)) }
+
+func (h *priorityHeap[T]) Pop() any {
+	n := len(h.vals) - 1
+	val := h.vals[n]
+	var zero T
+	h.vals[n] = zero
+	h.vals = h.vals[:n]
+	return val
+}
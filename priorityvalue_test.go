@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityValue(t *testing.T) {
+	p := NewPriorityValue(func(s string) int64 { return int64(len(s)) })
+	_, ok := p.Min()
+	assert.False(t, ok, "Min of an empty PriorityValue returned true.")
+	_, ok = p.Pop()
+	assert.False(t, ok, "Pop of an empty PriorityValue returned true.")
+
+	for _, s := range []string{"ccc", "a", "dddd", "bb"} {
+		p.Push(s)
+	}
+	min, ok := p.Min()
+	require.True(t, ok, "Min of a non-empty PriorityValue returned false.")
+	assert.Equal(t, "a", min, "Min didn't return the minimum.")
+
+	for _, want := range []string{"a", "bb", "ccc", "dddd"} {
+		val, ok := p.Pop()
+		require.True(t, ok, "Pop of a non-empty PriorityValue returned false.")
+		assert.Equal(t, want, val, "Pop didn't return the values in order.")
+	}
+	_, ok = p.Min()
+	assert.False(t, ok, "Min returned true after popping all values.")
+}
+
+func TestPriorityValueConcurrent(t *testing.T) {
+	const goroutines = 4
+
+	var (
+		p  = NewPriorityValue(func(v int) int64 { return int64(v) })
+		wg sync.WaitGroup
+	)
+	p.Push(-1)
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Push(i*100 + j)
+				min, ok := p.Min()
+				assert.True(t, ok && min == -1, "Min didn't return the minimum.")
+			}
+		}()
+	}
+	wg.Wait()
+
+	prev := -2
+	for {
+		val, ok := p.Pop()
+		if !ok {
+			break
+		}
+		assert.True(t, val > prev, "Pop returned %v after %v.", val, prev)
+		prev = val
+	}
+	assert.Equal(t, goroutines*100-1, prev, "Pop didn't return every value pushed.")
+}
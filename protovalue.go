@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// ProtoValue holds a message of type M, such as a generated protobuf message, that is cloned on every Load, so that
+// readers get an independent copy they may modify without racing with other readers. To avoid depending on a
+// protobuf package, the clone and equality functions are passed to NewProtoValue. For protobuf messages, these are
+// typically wrappers around proto.Clone and proto.Equal. ProtoValues must be created using NewProtoValue.
+type ProtoValue[M any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	clone func(M) M
+	equal func(a, b M) bool
+	v     Value[*protoEntry[M]]
+}
+
+// protoEntry holds a message stored to a ProtoValue. Entries are stored by pointer, so that compare-and-swap
+// operations on the ProtoValue do not depend on M being comparable.
+type protoEntry[M any] struct {
+	m M
+}
+
+// NewProtoValue creates a new, unset ProtoValue that clones messages using clone and compares them using equal.
+func NewProtoValue[M any](clone func(M) M, equal func(a, b M) bool) *ProtoValue[M] {
+	return &ProtoValue[M]{clone: clone, equal: equal}
+}
+
+// Load atomically loads the message held and returns a clone of it. Load returns the zero value of M if no message
+// was stored yet.
+func (p *ProtoValue[M]) Load() (m M) {
+	if e := p.v.Load(); e != nil {
+		return p.clone(e.m)
+	}
+	return m
+}
+
+// Store atomically stores m. m is stored as is, so the caller must not modify it after the call.
+func (p *ProtoValue[M]) Store(m M) {
+	p.v.Store(&protoEntry[M]{m: m})
+}
+
+// CompareAndSwap atomically stores new if the message held is equal to old, as reported by the equality function of
+// the ProtoValue, and reports whether it did so. Like Store, new is stored as is. CompareAndSwap never swaps if no
+// message was stored yet.
+func (p *ProtoValue[M]) CompareAndSwap(old, new M) (swapped bool) {
+	_, swapped = p.v.update(func(e *protoEntry[M]) (*protoEntry[M], bool) {
+		if e == nil || !p.equal(e.m, old) {
+			return nil, false
+		}
+		return &protoEntry[M]{m: new}, true
+	})
+	return swapped
+}
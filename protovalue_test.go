@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// protoMessage is a stand-in for a generated protobuf message.
+type protoMessage struct {
+	Name string
+	Tags []string
+}
+
+func cloneProtoMessage(m *protoMessage) *protoMessage {
+	return &protoMessage{Name: m.Name, Tags: append([]string(nil), m.Tags...)}
+}
+
+func equalProtoMessage(a, b *protoMessage) bool {
+	return a.Name == b.Name && assert.ObjectsAreEqual(a.Tags, b.Tags)
+}
+
+func TestProtoValue(t *testing.T) {
+	p := NewProtoValue(cloneProtoMessage, equalProtoMessage)
+	assert.Nil(t, p.Load(), "Load of an unset ProtoValue didn't return nil.")
+	assert.False(t, p.CompareAndSwap(nil, &protoMessage{}), "CompareAndSwap of an unset ProtoValue swapped.")
+
+	p.Store(&protoMessage{Name: "foo", Tags: []string{"a"}})
+	loaded := p.Load()
+	loaded.Name = "bar"
+	loaded.Tags[0] = "b"
+	assert.Equal(t, &protoMessage{Name: "foo", Tags: []string{"a"}}, p.Load(), "Load didn't return a clone.")
+
+	assert.False(t, p.CompareAndSwap(loaded, &protoMessage{Name: "baz"}), "CompareAndSwap of an unequal message swapped.")
+	assert.True(t, p.CompareAndSwap(&protoMessage{Name: "foo", Tags: []string{"a"}}, &protoMessage{Name: "baz"}),
+		"CompareAndSwap of an equal message didn't swap.")
+	assert.Equal(t, "baz", p.Load().Name, "CompareAndSwap didn't store the new message.")
+}
+
+func TestProtoValueConcurrentReaders(t *testing.T) {
+	var (
+		p  = NewProtoValue(cloneProtoMessage, equalProtoMessage)
+		wg sync.WaitGroup
+	)
+	p.Store(&protoMessage{Name: "foo", Tags: []string{"a"}})
+
+	wg.Add(8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m := p.Load()
+				m.Tags[0] = "modified"
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"a"}, p.Load().Tags, "readers modified the shared message.")
+}
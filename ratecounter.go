@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "time"
+
+// RateCounter is a counter that estimates the rate at which it is incremented, for example the number of operations
+// per second. Both counting and estimating the rate are lock-free. RateCounters must be created using
+// NewRateCounter.
+type RateCounter struct {
+	_ nocmp // disallow non-atomic comparison
+
+	c Counter
+
+	// now returns the current instant relative to stopwatchEpoch. It is replaced in tests.
+	now  func() time.Duration
+	last Int64
+}
+
+// NewRateCounter creates a new RateCounter at 0. The first call to Rate reports the rate since the RateCounter was
+// created.
+func NewRateCounter() *RateCounter {
+	c := &RateCounter{now: func() time.Duration { return time.Since(stopwatchEpoch) }}
+	c.last.Store(int64(c.now()))
+	return c
+}
+
+// Add atomically adds delta to the RateCounter and returns the new value.
+func (c *RateCounter) Add(delta int64) int64 {
+	return c.c.Add(delta)
+}
+
+// Inc atomically increments the RateCounter and returns the new value.
+func (c *RateCounter) Inc() int64 {
+	return c.c.Inc()
+}
+
+// Load atomically loads the current value of the RateCounter.
+func (c *RateCounter) Load() int64 {
+	return c.c.Load()
+}
+
+// Rate returns the average number of increments per second since the previous call to Rate, or since the
+// RateCounter was created if there was none, and starts a new interval for the next call. Rate returns 0 if no time
+// passed since the previous call.
+//
+// Like Counter.DeltaSinceLastRead, concurrent calls to Rate divide the interval and the increments within it between them,
+// so that each call reports the rate over a shorter interval.
+func (c *RateCounter) Rate() float64 {
+	delta := c.c.DeltaSinceLastRead()
+	now := int64(c.now())
+	elapsed := time.Duration(now - c.last.Swap(now))
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(delta) / elapsed.Seconds()
+}
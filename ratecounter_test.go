@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateCounter(t *testing.T) {
+	c := NewRateCounter()
+	var now time.Duration
+	c.now = func() time.Duration { return now }
+	c.last.Store(0)
+
+	for i := 0; i < 50; i++ {
+		c.Inc()
+	}
+	now += 500 * time.Millisecond
+	assert.Equal(t, 100.0, c.Rate(), "Rate didn't return the increments per second.")
+
+	c.Add(30)
+	now += 3 * time.Second
+	assert.Equal(t, 10.0, c.Rate(), "Rate didn't measure from the previous call.")
+
+	now += time.Second
+	assert.Equal(t, 0.0, c.Rate(), "Rate of an idle RateCounter wasn't 0.")
+	assert.Equal(t, 0.0, c.Rate(), "Rate without time passing wasn't 0.")
+	assert.Equal(t, int64(80), c.Load(), "Rate modified the count.")
+}
+
+func TestRateCounterClock(t *testing.T) {
+	c := NewRateCounter()
+	c.Add(10)
+	time.Sleep(10 * time.Millisecond)
+	rate := c.Rate()
+	assert.True(t, rate > 0 && rate <= 1000, "Rate with the real clock was out of range: %v", rate)
+}
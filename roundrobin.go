@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// RoundRobin cycles through a fixed set of values, returning the next value in the set on every call to Next. It is
+// safe for concurrent use and distributes values evenly between callers.
+type RoundRobin[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	vals []T
+	n    Uint64
+}
+
+// NewRoundRobin creates a new RoundRobin cycling through the values passed, starting with the first one. The slice
+// is copied, so modifying it afterwards does not affect the RoundRobin. NewRoundRobin panics if vals is empty.
+func NewRoundRobin[T any](vals []T) *RoundRobin[T] {
+	if len(vals) == 0 {
+		panic("atomic: NewRoundRobin called with no values")
+	}
+	return &RoundRobin[T]{vals: append([]T(nil), vals...)}
+}
+
+// Next atomically advances the RoundRobin and returns the next value, wrapping around to the first value after the
+// last one has been returned.
+//
+// The internal counter is a uint64 that wraps around to zero after 2^64 calls to Next. If the number of values is not
+// a power of two, the cycle restarts at the first value at that point, regardless of which value was returned last.
+func (r *RoundRobin[T]) Next() T {
+	return r.vals[(r.n.Inc()-1)%uint64(len(r.vals))]
+}
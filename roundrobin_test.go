@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundRobin(t *testing.T) {
+	vals := []string{"a", "b", "c"}
+	r := NewRoundRobin(vals)
+	vals[0] = "x"
+
+	for _, want := range []string{"a", "b", "c", "a", "b"} {
+		require.Equal(t, want, r.Next(), "Next returned the wrong value.")
+	}
+
+	assert.Panics(t, func() { NewRoundRobin[int](nil) }, "NewRoundRobin didn't panic without values.")
+
+	t.Run("counter wrap", func(t *testing.T) {
+		r := NewRoundRobin([]int{0, 1, 2})
+		r.n.Store(math.MaxUint64)
+		assert.Equal(t, int(uint64(math.MaxUint64)%3), r.Next(), "Next returned the wrong value before wrapping.")
+		assert.Equal(t, 0, r.Next(), "Next didn't restart at the first value after wrapping.")
+	})
+}
+
+func TestRoundRobinDistribution(t *testing.T) {
+	const (
+		goroutines = 8
+		calls      = 1000
+		values     = 4
+	)
+
+	var (
+		r      = NewRoundRobin([]int{0, 1, 2, 3})
+		counts [values]Int64
+		wg     sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				counts[r.Next()].Inc()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range counts {
+		assert.Equal(t, int64(goroutines*calls/values), counts[i].Load(), "value %v was returned an uneven number of times", i)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "fmt"
+
+// Semaphore is a non-blocking counting semaphore limiting the number of concurrent holders to a fixed maximum.
+// Acquiring and releasing the Semaphore is lock-free. Semaphores must be created using NewSemaphore.
+type Semaphore struct {
+	_ nocmp // disallow non-atomic comparison
+
+	n         int64
+	available Int64
+}
+
+// NewSemaphore creates a new Semaphore that may be held by up to n holders at a time. NewSemaphore panics if n is
+// negative.
+func NewSemaphore(n int64) *Semaphore {
+	if n < 0 {
+		panic(fmt.Sprintf("atomic: NewSemaphore called with negative n %v", n))
+	}
+	s := &Semaphore{n: n}
+	s.available.Store(n)
+	return s
+}
+
+// TryAcquire acquires the Semaphore if it has a unit available and reports whether it did so. TryAcquire never
+// blocks.
+func (s *Semaphore) TryAcquire() (acquired bool) {
+	for {
+		available := s.available.Load()
+		if available <= 0 {
+			return false
+		}
+		if s.available.CAS(available, available-1) {
+			return true
+		}
+	}
+}
+
+// Release releases a unit of the Semaphore acquired using TryAcquire. Release panics if the Semaphore is released
+// more often than it was acquired.
+func (s *Semaphore) Release() {
+	for {
+		available := s.available.Load()
+		if available >= s.n {
+			panic("atomic: Semaphore released more often than acquired")
+		}
+		if s.available.CAS(available, available+1) {
+			return
+		}
+	}
+}
+
+// Available returns the number of units currently available for acquiring. The value may be stale as soon as
+// Available returns.
+func (s *Semaphore) Available() int64 {
+	return s.available.Load()
+}
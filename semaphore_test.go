@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	s := NewSemaphore(2)
+	assert.Equal(t, int64(2), s.Available(), "new Semaphore didn't have n units available.")
+	assert.True(t, s.TryAcquire(), "TryAcquire of an available Semaphore failed.")
+	assert.True(t, s.TryAcquire(), "TryAcquire of an available Semaphore failed.")
+	assert.False(t, s.TryAcquire(), "TryAcquire of an exhausted Semaphore succeeded.")
+	assert.Equal(t, int64(0), s.Available(), "exhausted Semaphore had units available.")
+
+	s.Release()
+	assert.Equal(t, int64(1), s.Available(), "Release didn't make a unit available.")
+	s.Release()
+	assert.Panics(t, s.Release, "Release more often than acquired didn't panic.")
+	assert.Panics(t, func() { NewSemaphore(-1) }, "NewSemaphore with negative n didn't panic.")
+}
+
+func TestSemaphoreConcurrent(t *testing.T) {
+	const (
+		n          = 3
+		goroutines = 8
+		attempts   = 1000
+	)
+
+	var (
+		s       = NewSemaphore(n)
+		holders Int64
+		wg      sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < attempts; j++ {
+				if !s.TryAcquire() {
+					continue
+				}
+				if h := holders.Inc(); h > n {
+					assert.Fail(t, "Semaphore exceeded its limit.", "%v holders", h)
+				}
+				if a := s.Available(); a < 0 || a > n {
+					assert.Fail(t, "Semaphore count out of range.", "%v available", a)
+				}
+				holders.Dec()
+				s.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(n), s.Available(), "Semaphore didn't have all units available after all releases.")
+}
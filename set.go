@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// Set is a set of comparable values. Set is backed by a copy-on-write map: Contains and Snapshot are lock-free, and
+// Add and Remove atomically publish a modified copy of the map, retrying if another writer published one first. This
+// makes Set suitable for read-mostly workloads in which the set changes rarely.
+type Set[T comparable] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	m Value[*map[T]struct{}]
+}
+
+// NewSet creates a new Set holding the values passed.
+func NewSet[T comparable](vals ...T) *Set[T] {
+	m := make(map[T]struct{}, len(vals))
+	for _, v := range vals {
+		m[v] = struct{}{}
+	}
+	s := &Set[T]{}
+	s.m.Store(&m)
+	return s
+}
+
+// load returns the map currently held by the Set, which must not be modified.
+func (s *Set[T]) load() map[T]struct{} {
+	if m := s.m.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// Add atomically adds v to the Set and reports whether it was newly added.
+func (s *Set[T]) Add(v T) (added bool) {
+	_, added = s.m.update(func(old *map[T]struct{}) (*map[T]struct{}, bool) {
+		if old != nil {
+			if _, ok := (*old)[v]; ok {
+				return nil, false
+			}
+		}
+		m := copySetMap(old, 1)
+		m[v] = struct{}{}
+		return &m, true
+	})
+	return added
+}
+
+// Remove atomically removes v from the Set and reports whether it was present.
+func (s *Set[T]) Remove(v T) (removed bool) {
+	_, removed = s.m.update(func(old *map[T]struct{}) (*map[T]struct{}, bool) {
+		if old == nil {
+			return nil, false
+		}
+		if _, ok := (*old)[v]; !ok {
+			return nil, false
+		}
+		m := copySetMap(old, 0)
+		delete(m, v)
+		return &m, true
+	})
+	return removed
+}
+
+// copySetMap copies the map m points to, which may be nil, with room for extra additional values.
+func copySetMap[T comparable](m *map[T]struct{}, extra int) map[T]struct{} {
+	if m == nil {
+		return make(map[T]struct{}, extra)
+	}
+	cp := make(map[T]struct{}, len(*m)+extra)
+	for v := range *m {
+		cp[v] = struct{}{}
+	}
+	return cp
+}
+
+// Contains checks if v is in the Set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.load()[v]
+	return ok
+}
+
+// Len returns the number of values in the Set.
+func (s *Set[T]) Len() int {
+	return len(s.load())
+}
+
+// Snapshot returns the values in the Set at the time of the call, in no particular order.
+func (s *Set[T]) Snapshot() []T {
+	m := s.load()
+	vals := make([]T, 0, len(m))
+	for v := range m {
+		vals = append(vals, v)
+	}
+	return vals
+}
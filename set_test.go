@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet(1, 2)
+	require.True(t, s.Contains(1), "Contains didn't report an initial value.")
+	require.False(t, s.Contains(3), "Contains reported a value not in the Set.")
+
+	require.True(t, s.Add(3), "Add didn't report a new value as added.")
+	require.False(t, s.Add(3), "Add reported an existing value as added.")
+	require.True(t, s.Contains(3), "Contains didn't report an added value.")
+
+	require.True(t, s.Remove(1), "Remove didn't report a present value as removed.")
+	require.False(t, s.Remove(1), "Remove reported a missing value as removed.")
+	require.False(t, s.Contains(1), "Contains reported a removed value.")
+
+	require.Equal(t, 2, s.Len(), "Len returned the wrong number of values.")
+	require.ElementsMatch(t, []int{2, 3}, s.Snapshot(), "Snapshot returned the wrong values.")
+
+	t.Run("zero value", func(t *testing.T) {
+		var s Set[string]
+		assert.False(t, s.Contains("foo"), "Contains of an empty Set returned true.")
+		assert.False(t, s.Remove("foo"), "Remove of an empty Set returned true.")
+		assert.Empty(t, s.Snapshot(), "Snapshot of an empty Set wasn't empty.")
+		assert.True(t, s.Add("foo"), "Add to an empty Set returned false.")
+		assert.True(t, s.Contains("foo"), "Contains didn't report an added value.")
+	})
+}
+
+func TestSetConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		values     = 100
+	)
+
+	var (
+		s     Set[int]
+		added Int64
+		wg    sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for v := 0; v < values; v++ {
+				if s.Add(v) {
+					added.Inc()
+				}
+				s.Contains(v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(values), added.Load(), "Add reported a value as added more than once.")
+	assert.Equal(t, values, s.Len(), "Set doesn't hold every value added.")
+
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			for v := i; v < values; v += goroutines {
+				assert.True(t, s.Remove(v), "Remove didn't report a present value as removed.")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, s.Len(), "Set holds values after removing all of them.")
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ShardedCounter is an int64 counter split into multiple shards to reduce contention between goroutines that
+// increment it concurrently. Goroutines running on the same processor tend to use the same shard, so that writes
+// scale with the number of processors. In exchange, reading the counter requires summing all shards, which is
+// not atomic across them. ShardedCounters must be created using NewShardedCounter.
+type ShardedCounter struct {
+	_ nocmp // disallow non-atomic comparison
+
+	shards []paddedInt64
+	next   Uint32
+	pool   sync.Pool
+}
+
+// paddedInt64 is an Int64 padded to the size of a cache line, so that adjacent shards do not share one.
+type paddedInt64 struct {
+	Int64
+	_ [56]byte
+}
+
+// NewShardedCounter creates a new ShardedCounter with one shard per processor, as reported by runtime.GOMAXPROCS.
+func NewShardedCounter() *ShardedCounter {
+	n := runtime.GOMAXPROCS(0)
+	c := &ShardedCounter{shards: make([]paddedInt64, n)}
+	// sync.Pool keeps a per-processor cache, which is used here to hand out shard indices with processor affinity.
+	c.pool.New = func() any {
+		i := int(c.next.Inc()-1) % n
+		return &i
+	}
+	return c
+}
+
+// Add atomically adds delta to the ShardedCounter.
+func (c *ShardedCounter) Add(delta int64) {
+	i := c.pool.Get().(*int)
+	c.shards[*i].Add(delta)
+	c.pool.Put(i)
+}
+
+// Inc atomically increments the ShardedCounter.
+func (c *ShardedCounter) Inc() {
+	c.Add(1)
+}
+
+// Sum returns the sum of all shards of the ShardedCounter. Each shard is loaded atomically, but increments made
+// concurrently with Sum may or may not be included in the result.
+func (c *ShardedCounter) Sum() int64 {
+	var sum int64
+	for i := range c.shards {
+		sum += c.shards[i].Load()
+	}
+	return sum
+}
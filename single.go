@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "errors"
+
+// Single coalesces concurrent calls computing the same result, like a singleflight group limited to a single key.
+// At any time, a Single is either empty or has a call in flight: Do starts a call if the Single is empty, and joins
+// the call in flight otherwise, so that all concurrent callers receive the same result. Once the call returns, the
+// Single is empty again, and subsequent calls to Do start a new call. The zero value is an empty Single ready to use.
+type Single[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	call Value[*singleCall[T]]
+}
+
+// singleCall is a call to Single.Do in flight. val, err, panicked and panicVal may only be read once done is closed.
+// err is ErrGoexit if the function called runtime.Goexit.
+type singleCall[T any] struct {
+	done chan struct{}
+	// joined is the number of calls to Do that joined the call and wait for it to return.
+	joined Int32
+
+	val      T
+	err      error
+	panicked bool
+	panicVal any
+}
+
+// ErrGoexit is the error returned by Single.Do to the callers that joined a call in flight if the function called by
+// it called runtime.Goexit, for example through testing.T.FailNow.
+var ErrGoexit = errors.New("atomic: runtime.Goexit called by function passed to Single.Do")
+
+// Do calls fn and returns its results, unless a call to fn started by another call to Do is still in flight, in
+// which case Do waits for that call to return and returns its results instead. fn is never called concurrently by
+// the same Single. If fn panics, the panic is propagated to all callers of Do sharing the call: the caller that
+// called fn re-panics with the value recovered, and so does every caller that joined it. If fn calls runtime.Goexit,
+// the goroutine of the caller that called fn exits, while every caller that joined it returns ErrGoexit.
+func (s *Single[T]) Do(fn func() (T, error)) (T, error) {
+	c := &singleCall[T]{done: make(chan struct{})}
+	if current, started := s.call.update(func(old *singleCall[T]) (*singleCall[T], bool) {
+		return c, old == nil
+	}); !started {
+		current.joined.Inc()
+		<-current.done
+		if current.panicked {
+			panic(current.panicVal)
+		}
+		return current.val, current.err
+	}
+
+	returned, recovered := false, false
+	defer func() {
+		// If fn neither returned nor panicked, it called runtime.Goexit, which goes on to end the goroutine once this
+		// function returns.
+		if !returned && !recovered {
+			c.panicked, c.err = false, ErrGoexit
+		}
+		s.call.CompareAndSwap(c, nil)
+		close(c.done)
+		if c.panicked {
+			panic(c.panicVal)
+		}
+	}()
+	func() {
+		defer func() {
+			if !returned {
+				// recover returns nil both for runtime.Goexit and for a panic with a nil value. Only the latter is
+				// stopped by it, so that the function returns and sets recovered below.
+				c.panicked, c.panicVal = true, recover()
+			}
+		}()
+		c.val, c.err = fn()
+		returned = true
+	}()
+	recovered = !returned
+	return c.val, c.err
+}
+
+// InFlight reports whether a call to Do is currently in flight.
+func (s *Single[T]) InFlight() bool {
+	return s.call.Load() != nil
+}
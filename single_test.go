@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitJoined blocks until callers calls to Do share the call in flight of s.
+func waitJoined[T any](s *Single[T], callers int) {
+	for {
+		if c := s.call.Load(); c != nil && c.joined.Load() == int32(callers-1) {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestSingle(t *testing.T) {
+	const callers = 32
+
+	var (
+		s       Single[int]
+		calls   Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	fn := func() (int, error) {
+		calls.Inc()
+		<-release
+		return 42, nil
+	}
+
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			val, err := s.Do(fn)
+			assert.NoError(t, err, "Do returned an unexpected error.")
+			assert.Equal(t, 42, val, "Do didn't return the result of the call in flight.")
+		}()
+	}
+	waitJoined(&s, callers)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "Do didn't coalesce concurrent calls.")
+	assert.False(t, s.InFlight(), "Single has a call in flight after all calls returned.")
+
+	errFoo := errors.New("foo")
+	_, err := s.Do(func() (int, error) { return 0, errFoo })
+	assert.Equal(t, errFoo, err, "Do of an empty Single didn't start a new call.")
+}
+
+func TestSinglePanic(t *testing.T) {
+	const callers = 4
+
+	var (
+		s       Single[int]
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	fn := func() (int, error) {
+		<-release
+		panic("foo")
+	}
+
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			assert.PanicsWithValue(t, "foo", func() { _, _ = s.Do(fn) }, "Do didn't propagate the panic of fn.")
+		}()
+	}
+	waitJoined(&s, callers)
+	close(release)
+	wg.Wait()
+
+	assert.False(t, s.InFlight(), "Single has a call in flight after fn panicked.")
+	val, err := s.Do(func() (int, error) { return 1, nil })
+	assert.NoError(t, err, "Do after a panic returned an unexpected error.")
+	assert.Equal(t, 1, val, "Do after a panic didn't start a new call.")
+}
+
+func TestSingleGoexit(t *testing.T) {
+	const callers = 4
+
+	var (
+		s       Single[int]
+		release = make(chan struct{})
+		exited  Int32
+		wg      sync.WaitGroup
+	)
+	fn := func() (int, error) {
+		<-release
+		runtime.Goexit()
+		return 1, nil
+	}
+
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			returned := false
+			defer func() {
+				if !returned {
+					exited.Inc()
+				}
+			}()
+			_, err := s.Do(fn)
+			returned = true
+			assert.Equal(t, ErrGoexit, err, "Do didn't return ErrGoexit to a caller that joined the call.")
+		}()
+	}
+	waitJoined(&s, callers)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), exited.Load(), "Goexit didn't end only the goroutine of the caller that called fn.")
+	assert.False(t, s.InFlight(), "Single has a call in flight after fn called Goexit.")
+	val, err := s.Do(func() (int, error) { return 2, nil })
+	assert.NoError(t, err, "Do after Goexit returned an unexpected error.")
+	assert.Equal(t, 2, val, "Do after Goexit didn't start a new call.")
+}
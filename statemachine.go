@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "fmt"
+
+// StateMachine holds a state of type S that may only change through the transitions registered when it is created.
+// Transitions are applied atomically using compare-and-swap, so that concurrent callers can not move the
+// StateMachine through a transition that is not permitted from the state it is actually in. StateMachines must be
+// created using NewStateMachine.
+type StateMachine[S comparable] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	state       Value[S]
+	transitions map[S]map[S]struct{}
+}
+
+// NewStateMachine creates a new StateMachine in the initial state passed. transitions maps every state to the states
+// that may be transitioned to from it. The map is copied, so modifying it afterwards does not affect the
+// StateMachine.
+func NewStateMachine[S comparable](initial S, transitions map[S][]S) *StateMachine[S] {
+	m := &StateMachine[S]{transitions: make(map[S]map[S]struct{}, len(transitions))}
+	for from, targets := range transitions {
+		set := make(map[S]struct{}, len(targets))
+		for _, to := range targets {
+			set[to] = struct{}{}
+		}
+		m.transitions[from] = set
+	}
+	m.state.Store(initial)
+	return m
+}
+
+// State atomically loads the current state of the StateMachine.
+func (m *StateMachine[S]) State() S {
+	return m.state.Load()
+}
+
+// Transition atomically moves the StateMachine to the state to, if a transition to it is permitted from the current
+// state. If it is not, Transition returns false and a *TransitionError holding the current and target states.
+func (m *StateMachine[S]) Transition(to S) (ok bool, err error) {
+	for {
+		from := m.state.Load()
+		if _, permitted := m.transitions[from][to]; !permitted {
+			return false, &TransitionError[S]{From: from, To: to}
+		}
+		if m.state.CompareAndSwap(from, to) {
+			return true, nil
+		}
+	}
+}
+
+// TransitionError is returned by StateMachine.Transition if a transition is attempted that is not permitted from the
+// current state.
+type TransitionError[S comparable] struct {
+	// From is the state the StateMachine was in.
+	From S
+	// To is the state that could not be transitioned to.
+	To S
+}
+
+// Error returns a message describing the transition that is not permitted.
+func (err *TransitionError[S]) Error() string {
+	return fmt.Sprintf("atomic: illegal state transition from %v to %v", err.From, err.To)
+}
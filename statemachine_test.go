@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type connState string
+
+const (
+	connIdle       connState = "idle"
+	connConnecting connState = "connecting"
+	connConnected  connState = "connected"
+)
+
+func newConnStateMachine() *StateMachine[connState] {
+	return NewStateMachine(connIdle, map[connState][]connState{
+		connIdle:       {connConnecting},
+		connConnecting: {connConnected, connIdle},
+		connConnected:  {connIdle},
+	})
+}
+
+func TestStateMachine(t *testing.T) {
+	m := newConnStateMachine()
+	require.Equal(t, connIdle, m.State(), "StateMachine didn't start in the initial state.")
+
+	ok, err := m.Transition(connConnecting)
+	require.NoError(t, err, "Legal transition errored.")
+	require.True(t, ok, "Legal transition wasn't applied.")
+	require.Equal(t, connConnecting, m.State(), "Transition didn't change the state.")
+
+	ok, err = m.Transition(connConnecting)
+	require.False(t, ok, "Illegal transition was applied.")
+	assert.Equal(t, &TransitionError[connState]{From: connConnecting, To: connConnecting}, err,
+		"Illegal transition returned an unexpected error.")
+	assert.EqualError(t, err, "atomic: illegal state transition from connecting to connecting")
+	require.Equal(t, connConnecting, m.State(), "Illegal transition changed the state.")
+
+	ok, err = m.Transition(connConnected)
+	require.NoError(t, err, "Legal transition errored.")
+	require.True(t, ok, "Legal transition wasn't applied.")
+}
+
+func TestStateMachineConcurrent(t *testing.T) {
+	const goroutines = 8
+
+	var (
+		m         = newConnStateMachine()
+		succeeded Int32
+		wg        sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			ok, err := m.Transition(connConnecting)
+			if ok {
+				succeeded.Inc()
+				return
+			}
+			assert.Error(t, err, "Failed transition didn't return an error.")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), succeeded.Load(), "Transition from idle to connecting was applied more than once.")
+	assert.Equal(t, connConnecting, m.State(), "StateMachine isn't in the expected state.")
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+// StickyValue is a value of type T whose Load returns the last non-zero value stored, even if a zero value was stored
+// after it. The last value stored, zero or not, may be loaded using LoadRaw. StickyValues must be created using
+// NewStickyValue or NewStickyValueFunc.
+type StickyValue[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v      Value[*stickyEntry[T]]
+	isZero func(T) bool
+}
+
+// stickyEntry holds the values of a StickyValue, which are published together so that they are always consistent
+// with each other.
+type stickyEntry[T any] struct {
+	sticky, raw T
+}
+
+// NewStickyValue creates a new StickyValue holding the zero value of T. A stored value is considered zero if it is
+// equal to the zero value of T.
+func NewStickyValue[T comparable]() *StickyValue[T] {
+	return NewStickyValueFunc(func(val T) bool {
+		var zero T
+		return val == zero
+	})
+}
+
+// NewStickyValueFunc creates a new StickyValue holding the zero value of T. A stored value is considered zero if
+// isZero returns true for it. Unlike NewStickyValue, NewStickyValueFunc may be used if T is an uncomparable type.
+func NewStickyValueFunc[T any](isZero func(T) bool) *StickyValue[T] {
+	return &StickyValue[T]{isZero: isZero}
+}
+
+// Store atomically stores val. If val is zero, only the value returned by LoadRaw is changed, and Load keeps
+// returning the last non-zero value stored.
+func (s *StickyValue[T]) Store(val T) {
+	zero := s.isZero(val)
+	s.v.update(func(old *stickyEntry[T]) (*stickyEntry[T], bool) {
+		e := &stickyEntry[T]{sticky: val, raw: val}
+		if zero {
+			e.sticky = s.sticky(old)
+		}
+		return e, true
+	})
+}
+
+// sticky returns the sticky value held by e, or the zero value of T if e is nil.
+func (s *StickyValue[T]) sticky(e *stickyEntry[T]) (val T) {
+	if e != nil {
+		val = e.sticky
+	}
+	return val
+}
+
+// Load atomically loads the last non-zero value stored, or the zero value of T if no non-zero value was stored yet.
+func (s *StickyValue[T]) Load() T {
+	return s.sticky(s.v.Load())
+}
+
+// LoadRaw atomically loads the last value stored, regardless of whether it is zero.
+func (s *StickyValue[T]) LoadRaw() T {
+	if e := s.v.Load(); e != nil {
+		return e.raw
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStickyValue(t *testing.T) {
+	s := NewStickyValue[int]()
+	assert.Equal(t, 0, s.Load(), "new StickyValue didn't hold zero.")
+
+	s.Store(3)
+	s.Store(0)
+	assert.Equal(t, 3, s.Load(), "storing zero cleared the sticky value.")
+	assert.Equal(t, 0, s.LoadRaw(), "LoadRaw didn't return the last value stored.")
+
+	s.Store(5)
+	assert.Equal(t, 5, s.Load(), "Load didn't return the last non-zero value.")
+	assert.Equal(t, 5, s.LoadRaw(), "LoadRaw didn't return the last value stored.")
+}
+
+func TestStickyValueFunc(t *testing.T) {
+	s := NewStickyValueFunc(func(b []byte) bool { return len(b) == 0 })
+	s.Store([]byte("foo"))
+	s.Store([]byte{})
+	s.Store(nil)
+	assert.Equal(t, []byte("foo"), s.Load(), "storing zero cleared the sticky value.")
+	assert.Nil(t, s.LoadRaw(), "LoadRaw didn't return the last value stored.")
+}
+
+func TestStickyValueConcurrent(t *testing.T) {
+	const goroutines = 4
+
+	for round := 0; round < 2000; round++ {
+		var (
+			s     = NewStickyValue[int]()
+			start = make(chan struct{})
+			wg    sync.WaitGroup
+		)
+		wg.Add(goroutines)
+		for i := 1; i <= goroutines; i++ {
+			i := i
+			go func() {
+				defer wg.Done()
+				<-start
+				s.Store(i)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		require.Equal(t, s.LoadRaw(), s.Load(), "Load didn't return the last non-zero value stored.")
+	}
+}
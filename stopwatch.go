@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "time"
+
+// stopwatchEpoch is the instant that Stopwatch start times are measured from. Measuring from a fixed time.Time
+// keeps the readings monotonic, while still allowing them to be stored as a single int64.
+var stopwatchEpoch = time.Now()
+
+// Stopwatch measures the time elapsed since it was started, and may be started, read and reset from any number of
+// goroutines concurrently. The start instant is stored as nanoseconds in an Int64, so that reading the Stopwatch is
+// lock-free. The zero value is a Stopwatch that was not started.
+type Stopwatch struct {
+	_ nocmp // disallow non-atomic comparison
+
+	// start holds the nanoseconds between stopwatchEpoch and the start instant, plus one, so that 0 unambiguously
+	// means the Stopwatch is not running.
+	start Int64
+}
+
+// stopwatchNow returns the current instant as stored in Stopwatch.start.
+func stopwatchNow() int64 {
+	return int64(time.Since(stopwatchEpoch)) + 1
+}
+
+// Start (re)starts the Stopwatch at the current instant. Starting a Stopwatch that is already running restarts it,
+// so that Elapsed is measured from the latest call to Start.
+func (s *Stopwatch) Start() {
+	s.start.Store(stopwatchNow())
+}
+
+// Elapsed returns the time elapsed since the Stopwatch was last started, or 0 if it is not running.
+func (s *Stopwatch) Elapsed() time.Duration {
+	start := s.start.Load()
+	if start == 0 {
+		return 0
+	}
+	return time.Duration(stopwatchNow() - start)
+}
+
+// Running reports whether the Stopwatch was started and not reset since.
+func (s *Stopwatch) Running() bool {
+	return s.start.Load() != 0
+}
+
+// Reset stops the Stopwatch, so that Elapsed returns 0 until it is started again.
+func (s *Stopwatch) Reset() {
+	s.start.Store(0)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopwatch(t *testing.T) {
+	var s Stopwatch
+	assert.False(t, s.Running(), "zero Stopwatch is running.")
+	assert.Equal(t, time.Duration(0), s.Elapsed(), "zero Stopwatch has elapsed time.")
+
+	s.Start()
+	assert.True(t, s.Running(), "started Stopwatch isn't running.")
+	time.Sleep(10 * time.Millisecond)
+	elapsed := s.Elapsed()
+	assert.True(t, elapsed >= 10*time.Millisecond, "Elapsed is shorter than the time slept.")
+	assert.True(t, s.Elapsed() >= elapsed, "Elapsed decreased without a restart.")
+
+	s.Start()
+	assert.True(t, s.Elapsed() < elapsed, "Start didn't restart the Stopwatch.")
+
+	s.Reset()
+	assert.False(t, s.Running(), "reset Stopwatch is running.")
+	assert.Equal(t, time.Duration(0), s.Elapsed(), "reset Stopwatch has elapsed time.")
+}
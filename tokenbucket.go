@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"math"
+	"time"
+)
+
+// TokenBucket is a lock-free token bucket rate limiter. The bucket holds at most a fixed number of tokens, its burst,
+// and is refilled continuously at a fixed rate. Every call to Allow that is permitted consumes one token.
+// TokenBuckets must be created using NewTokenBucket.
+type TokenBucket struct {
+	_ nocmp // disallow non-atomic comparison
+
+	rate, burst float64
+	state       Value[*tokenBucketState]
+}
+
+// tokenBucketState is the immutable state of a TokenBucket. A new tokenBucketState is published on every change.
+type tokenBucketState struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a new, full TokenBucket that is refilled with rate tokens per second and holds at most
+// burst tokens.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	b := &TokenBucket{rate: rate, burst: float64(burst)}
+	b.state.Store(&tokenBucketState{tokens: b.burst})
+	return b
+}
+
+// Allow refills the TokenBucket based on the time elapsed between the previous call to Allow and now, and consumes
+// a token if one is available. Allow reports whether a token was consumed. Calls with a time before that of the
+// previous call do not refill the bucket.
+func (b *TokenBucket) Allow(now time.Time) bool {
+	for {
+		current := b.state.Load()
+		tokens, last := current.tokens, current.last
+		if now.After(last) {
+			tokens = math.Min(b.burst, tokens+now.Sub(last).Seconds()*b.rate)
+			last = now
+		}
+		if tokens < 1 {
+			return false
+		}
+		if b.state.CompareAndSwap(current, &tokenBucketState{tokens: tokens - 1, last: last}) {
+			return true
+		}
+	}
+}
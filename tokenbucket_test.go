@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2021, 6, 17, 9, 0, 0, 0, time.UTC)
+	b := NewTokenBucket(2, 3)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, b.Allow(now), "Allow of a full bucket returned false.")
+	}
+	require.False(t, b.Allow(now), "Allow of an empty bucket returned true.")
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, b.Allow(now), "Allow didn't refill the bucket.")
+	require.False(t, b.Allow(now), "Allow refilled the bucket by more than the rate.")
+
+	require.False(t, b.Allow(now.Add(-time.Second)), "Allow refilled the bucket for a time in the past.")
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.True(t, b.Allow(now), "Allow didn't refill the bucket.")
+	}
+	assert.False(t, b.Allow(now), "Allow refilled the bucket beyond its burst.")
+}
+
+func TestTokenBucketConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		calls      = 100
+		burst      = 10
+	)
+
+	var (
+		now     = time.Date(2021, 6, 17, 9, 0, 0, 0, time.UTC)
+		b       = NewTokenBucket(1, burst)
+		allowed Int64
+		wg      sync.WaitGroup
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				if b.Allow(now) {
+					allowed.Inc()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(burst), allowed.Load(), "Allow permitted a number of calls other than the burst.")
+}
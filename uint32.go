@@ -1,6 +1,6 @@
 // @generated Code generated by gen-atomicint.
 
-// Copyright (c) 2020-2021 Uber Technologies, Inc.
+// Copyright (c) 2020-2026 Uber Technologies, Inc.
 //
 // Permission is hereby granted, free of charge, to any person obtaining a copy
 // of this software and associated documentation files (the "Software"), to deal
@@ -80,6 +80,67 @@ func (i *Uint32) Swap(val uint32) (old uint32) {
 	return atomic.SwapUint32(&i.v, val)
 }
 
+// SwapZero atomically resets the wrapped uint32 to zero and returns the
+// old value. It may be used to read and reset a counter at the end of an
+// interval without losing increments made concurrently.
+func (i *Uint32) SwapZero() (old uint32) {
+	return i.Swap(0)
+}
+
+// AddSaturating atomically adds delta to the wrapped uint32, limiting the
+// result to max, and returns the new value. An addition that would exceed max or
+// overflow stores max instead. If the wrapped uint32 is already
+// greater than max, AddSaturating stores max.
+func (i *Uint32) AddSaturating(delta, max uint32) uint32 {
+	for {
+		old := i.Load()
+		new := old + delta
+		if new > max || new < old {
+			new = max
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from the wrapped uint32,
+// limiting the result to min, and returns the new value. A subtraction that
+// would go below min or underflow stores min instead. If the wrapped uint32 is
+// already less than min, SubSaturating stores min.
+func (i *Uint32) SubSaturating(delta, min uint32) uint32 {
+	for {
+		old := i.Load()
+		new := old - delta
+		if new < min || new > old {
+			new = min
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped uint32 and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (i *Uint32) GreaterThan(v uint32) bool {
+	return i.Load() > v
+}
+
+// LessThan atomically loads the wrapped uint32 and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (i *Uint32) LessThan(v uint32) bool {
+	return i.Load() < v
+}
+
+// Between atomically loads the wrapped uint32 and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot.
+func (i *Uint32) Between(lo, hi uint32) bool {
+	v := i.Load()
+	return v >= lo && v <= hi
+}
+
 // MarshalJSON encodes the wrapped uint32 into JSON.
 func (i *Uint32) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Load())
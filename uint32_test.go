@@ -44,6 +44,16 @@ func TestUint32(t *testing.T) {
 	require.Equal(t, uint32(0), atom.Swap(1), "Swap didn't return the old value.")
 	require.Equal(t, uint32(1), atom.Load(), "Swap didn't set the correct value.")
 
+	require.Equal(t, uint32(1), atom.SwapZero(), "SwapZero didn't return the old value.")
+	require.Equal(t, uint32(0), atom.Load(), "SwapZero didn't reset the value.")
+
+	require.Equal(t, uint32(5), atom.AddSaturating(5, 6), "AddSaturating below max didn't add.")
+	require.Equal(t, uint32(6), atom.AddSaturating(5, 6), "AddSaturating beyond max didn't saturate.")
+	require.Equal(t, uint32(math.MaxUint32), atom.AddSaturating(math.MaxUint32, math.MaxUint32),
+		"AddSaturating didn't saturate on overflow.")
+	require.Equal(t, uint32(2), atom.SubSaturating(math.MaxUint32-2, 1), "SubSaturating above min didn't subtract.")
+	require.Equal(t, uint32(0), atom.SubSaturating(3, 0), "SubSaturating didn't saturate on underflow.")
+
 	atom.Store(42)
 	require.Equal(t, uint32(42), atom.Load(), "Store didn't set the correct value.")
 
@@ -1,6 +1,6 @@
 // @generated Code generated by gen-atomicint.
 
-// Copyright (c) 2020-2021 Uber Technologies, Inc.
+// Copyright (c) 2020-2026 Uber Technologies, Inc.
 //
 // Permission is hereby granted, free of charge, to any person obtaining a copy
 // of this software and associated documentation files (the "Software"), to deal
@@ -80,6 +80,67 @@ func (i *Uint64) Swap(val uint64) (old uint64) {
 	return atomic.SwapUint64(&i.v, val)
 }
 
+// SwapZero atomically resets the wrapped uint64 to zero and returns the
+// old value. It may be used to read and reset a counter at the end of an
+// interval without losing increments made concurrently.
+func (i *Uint64) SwapZero() (old uint64) {
+	return i.Swap(0)
+}
+
+// AddSaturating atomically adds delta to the wrapped uint64, limiting the
+// result to max, and returns the new value. An addition that would exceed max or
+// overflow stores max instead. If the wrapped uint64 is already
+// greater than max, AddSaturating stores max.
+func (i *Uint64) AddSaturating(delta, max uint64) uint64 {
+	for {
+		old := i.Load()
+		new := old + delta
+		if new > max || new < old {
+			new = max
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from the wrapped uint64,
+// limiting the result to min, and returns the new value. A subtraction that
+// would go below min or underflow stores min instead. If the wrapped uint64 is
+// already less than min, SubSaturating stores min.
+func (i *Uint64) SubSaturating(delta, min uint64) uint64 {
+	for {
+		old := i.Load()
+		new := old - delta
+		if new < min || new > old {
+			new = min
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped uint64 and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (i *Uint64) GreaterThan(v uint64) bool {
+	return i.Load() > v
+}
+
+// LessThan atomically loads the wrapped uint64 and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (i *Uint64) LessThan(v uint64) bool {
+	return i.Load() < v
+}
+
+// Between atomically loads the wrapped uint64 and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot.
+func (i *Uint64) Between(lo, hi uint64) bool {
+	v := i.Load()
+	return v >= lo && v <= hi
+}
+
 // MarshalJSON encodes the wrapped uint64 into JSON.
 func (i *Uint64) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Load())
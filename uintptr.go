@@ -1,6 +1,6 @@
 // @generated Code generated by gen-atomicint.
 
-// Copyright (c) 2020-2021 Uber Technologies, Inc.
+// Copyright (c) 2020-2026 Uber Technologies, Inc.
 //
 // Permission is hereby granted, free of charge, to any person obtaining a copy
 // of this software and associated documentation files (the "Software"), to deal
@@ -80,6 +80,67 @@ func (i *Uintptr) Swap(val uintptr) (old uintptr) {
 	return atomic.SwapUintptr(&i.v, val)
 }
 
+// SwapZero atomically resets the wrapped uintptr to zero and returns the
+// old value. It may be used to read and reset a counter at the end of an
+// interval without losing increments made concurrently.
+func (i *Uintptr) SwapZero() (old uintptr) {
+	return i.Swap(0)
+}
+
+// AddSaturating atomically adds delta to the wrapped uintptr, limiting the
+// result to max, and returns the new value. An addition that would exceed max or
+// overflow stores max instead. If the wrapped uintptr is already
+// greater than max, AddSaturating stores max.
+func (i *Uintptr) AddSaturating(delta, max uintptr) uintptr {
+	for {
+		old := i.Load()
+		new := old + delta
+		if new > max || new < old {
+			new = max
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from the wrapped uintptr,
+// limiting the result to min, and returns the new value. A subtraction that
+// would go below min or underflow stores min instead. If the wrapped uintptr is
+// already less than min, SubSaturating stores min.
+func (i *Uintptr) SubSaturating(delta, min uintptr) uintptr {
+	for {
+		old := i.Load()
+		new := old - delta
+		if new < min || new > old {
+			new = min
+		}
+		if i.CAS(old, new) {
+			return new
+		}
+	}
+}
+
+// GreaterThan atomically loads the wrapped uintptr and reports whether it is
+// greater than v. Every call is an independent snapshot of the value.
+func (i *Uintptr) GreaterThan(v uintptr) bool {
+	return i.Load() > v
+}
+
+// LessThan atomically loads the wrapped uintptr and reports whether it is
+// less than v. Every call is an independent snapshot of the value.
+func (i *Uintptr) LessThan(v uintptr) bool {
+	return i.Load() < v
+}
+
+// Between atomically loads the wrapped uintptr and reports whether it lies
+// within lo and hi, inclusive. The value is loaded once, so that both bounds are
+// compared with the same snapshot.
+func (i *Uintptr) Between(lo, hi uintptr) bool {
+	v := i.Load()
+	return v >= lo && v <= hi
+}
+
 // MarshalJSON encodes the wrapped uintptr into JSON.
 func (i *Uintptr) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.Load())
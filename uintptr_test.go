@@ -44,6 +44,9 @@ func TestUintptr(t *testing.T) {
 	require.Equal(t, uintptr(0), atom.Swap(1), "Swap didn't return the old value.")
 	require.Equal(t, uintptr(1), atom.Load(), "Swap didn't set the correct value.")
 
+	require.Equal(t, uintptr(1), atom.SwapZero(), "SwapZero didn't return the old value.")
+	require.Equal(t, uintptr(0), atom.Load(), "SwapZero didn't reset the value.")
+
 	atom.Store(42)
 	require.Equal(t, uintptr(42), atom.Load(), "Store didn't set the correct value.")
 
@@ -77,3 +80,27 @@ func TestUintptr(t *testing.T) {
 			"String() returned an unexpected value.")
 	})
 }
+
+func BenchmarkUintptr(b *testing.B) {
+	b.Run("Uintptr", func(b *testing.B) {
+		b.ReportAllocs()
+		var atom Uintptr
+		for i := 0; i < b.N; i++ {
+			atom.Store(uintptr(i))
+			if atom.Load() != uintptr(i) {
+				b.Fatal("Load returned the wrong value.")
+			}
+		}
+	})
+
+	b.Run("Value", func(b *testing.B) {
+		b.ReportAllocs()
+		var v Value[uintptr]
+		for i := 0; i < b.N; i++ {
+			v.Store(uintptr(i))
+			if v.Load() != uintptr(i) {
+				b.Fatal("Load returned the wrong value.")
+			}
+		}
+	})
+}
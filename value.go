@@ -21,21 +21,79 @@
 package atomic
 
 import (
+	"context"
+	"encoding"
+	"errors"
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // Value is a wrapper around atomic.Value with a generic API. Note that for basic types such as int, float and bool
 // types, using atomic.(U)Int*, atomic.Float* and atomic.Bool is more efficient.
 // https://godoc.org/sync/atomic#Value
+//
+// Unlike atomic.Value, a Value[T] where T is an interface type may hold values of different concrete types over its
+// lifetime. A Value[any] may, for example, first hold an int and later a string.
 type Value[T any] struct {
 	atomic.Value
 
 	_ nocmp // disallow non-atomic comparison
+
+	ext UnsafePointer
+}
+
+// valueExt holds the state of a Value that only few Values use, such as its options, hooks, watchers and the frozen
+// flag. It is allocated the first time any of it is needed, or by NewValue if options are passed, so that other
+// Values stay small and their writes only pay for loading Value.ext.
+type valueExt[T any] struct {
+	cfg       valueConfig[T]
+	stringer  atomic.Value
+	onReplace atomic.Value
+	onCASFail atomic.Value
+	frozen    Bool
+	writers   Int32
+	ready     readySignal
+	watchers  valueWatchers[T]
+}
+
+// extension returns the valueExt of the Value, or nil if none was allocated yet.
+func (v *Value[T]) extension() *valueExt[T] {
+	return (*valueExt[T])(v.ext.Load())
+}
+
+// loadOrCreateExtension returns the valueExt of the Value, allocating it if it does not exist yet. Concurrent calls
+// always return the same valueExt.
+func (v *Value[T]) loadOrCreateExtension() *valueExt[T] {
+	if e := v.extension(); e != nil {
+		return e
+	}
+	v.ext.CAS(nil, unsafe.Pointer(newValueExt[T]()))
+	return v.extension()
+}
+
+// newValueExt allocates a valueExt with a zero valueConfig.
+func newValueExt[T any]() *valueExt[T] {
+	return &valueExt[T]{ready: readySignal{ch: make(chan struct{})}}
+}
+
+// config returns the valueConfig of the Value, or nil if no valueExt was allocated yet, in which case the Value was
+// created without options.
+func (v *Value[T]) config() *valueConfig[T] {
+	if e := v.extension(); e != nil {
+		return &e.cfg
+	}
+	return nil
 }
 
 // wrapper is a wrapper struct around an arbitrary type T. This wrapper is required for atomic.Values that want to
-// store an interface type, because these are "inconsistently typed".
+// store an interface type, because these are "inconsistently typed". Because the concrete type stored in the
+// atomic.Value is always wrapper[T], storing values of different concrete types in a Value[T] does not panic.
 type wrapper[T any] struct{ val T }
 
 // wrap packs a value of type T into a wrapper.
@@ -50,49 +108,1123 @@ func unwrap[T any](val any) T {
 }
 
 // NewValue creates a Value[T] and assigns to it the value passed. NewValue returns a pointer to the Value[T] created.
-func NewValue[T any](val T) *Value[T] {
+// The Value is configured using the options passed, if any.
+func NewValue[T any](val T, opts ...ValueOption[T]) *Value[T] {
 	var v Value[T]
+	if len(opts) > 0 {
+		e := newValueExt[T]()
+		for _, opt := range opts {
+			opt(&e.cfg)
+		}
+		v.ext.Store(unsafe.Pointer(e))
+	}
 	v.Store(val)
 	return &v
 }
 
+// NewFromChannel creates an unset Value[T] that is kept updated with the values received from ch. A goroutine is
+// started that stores every value received until ctx is done or ch is closed, at which point it exits, leaving the
+// Value holding the last value received. The goroutine lives as long as both ctx and ch, so ctx should be cancelled
+// once the Value is no longer needed if ch is never closed. NewFromChannel returns a pointer to the Value created.
+func NewFromChannel[T any](ctx context.Context, ch <-chan T) *Value[T] {
+	v, _ := newFromChannel(ctx, ch)
+	return v
+}
+
+// newFromChannel implements NewFromChannel, additionally returning a channel that is closed once the goroutine
+// started exits.
+func newFromChannel[T any](ctx context.Context, ch <-chan T) (*Value[T], <-chan struct{}) {
+	v, done := &Value[T]{}, make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case val, ok := <-ch:
+				if !ok {
+					return
+				}
+				v.Store(val)
+			}
+		}
+	}()
+	return v, done
+}
+
+// NewValueWithDefault creates a Value[T] holding def, which is also kept as the default value of the Value that
+// ResetToDefault restores. The Value is configured using the options passed, if any.
+func NewValueWithDefault[T any](def T, opts ...ValueOption[T]) *Value[T] {
+	// Copy opts before appending, so that the caller's backing array is never written to.
+	return NewValue(def, append(append([]ValueOption[T](nil), opts...), func(cfg *valueConfig[T]) {
+		if cfg.clone != nil {
+			def = cfg.clone(def)
+		}
+		cfg.def = def
+	})...)
+}
+
+// ValueOption is an option that may be passed to NewValue to configure the Value created.
+type ValueOption[T any] func(cfg *valueConfig[T])
+
+// valueConfig holds the configuration of a Value, as set by ValueOptions. It is part of the valueExt of the Value and
+// is not modified after the Value is created.
+type valueConfig[T any] struct {
+	clone      func(T) T
+	def        T
+	stats      *valueStats
+	lastWriter *atomic.Value
+}
+
+// StoreClones returns a ValueOption that makes the Value pass every value written to it through clone before storing
+// it, including the value passed to NewValue. This isolates the value held from the caller, which may, for example,
+// keep modifying a slice after storing it. Note that this comes at the cost of calling clone, which typically
+// allocates, on every write.
+func StoreClones[T any](clone func(T) T) ValueOption[T] {
+	return func(cfg *valueConfig[T]) {
+		cfg.clone = clone
+	}
+}
+
+// CollectStats returns a ValueOption that makes the Value count the operations performed on it, which may be
+// retrieved using Value.Stats. Values created without CollectStats do not count operations. Writes to a Value created
+// without any options only check that it has none on top of the write itself, as long as no hooks, watchers or other
+// optional state were set up for it either.
+func CollectStats[T any]() ValueOption[T] {
+	return func(cfg *valueConfig[T]) {
+		cfg.stats = &valueStats{}
+	}
+}
+
+// RecordLastWriter returns a ValueOption that makes the Value record the location of the code that last wrote to it,
+// which may be retrieved using Value.LastWriter. This is meant for debugging unexpected modifications of a Value, and
+// has a considerable overhead, as every write captures a stack trace using runtime.Callers.
+func RecordLastWriter[T any]() ValueOption[T] {
+	return func(cfg *valueConfig[T]) {
+		cfg.lastWriter = &atomic.Value{}
+	}
+}
+
+// _valuePkg is the package path of Value, followed by a dot, which prefixes the names of all functions of this
+// package in stack traces.
+var _valuePkg = reflect.TypeOf(valueStats{}).PkgPath() + "."
+
+// _valueFuncs are the prefixes of the functions that are skipped when recording the last writer of a Value, after
+// _valuePkg. They include the methods of Value and the helper types and functions it passes writes through.
+var _valueFuncs = []string{
+	"(*Value[", "fmtScanner[", "NewValue[", "NewValueWithDefault[", "NewZeroValue[", "NewFromChannel[", "newFromChannel[",
+	"UpdateEmit[", "Transfer[", "StoreAt[", "CompareAndSwapAt[", "StoreIfNewPointer[",
+}
+
+// _writerSkipPkgs are the prefixes of functions outside of this package that are skipped when recording the last
+// writer of a Value, as they call the helpers of Value on behalf of the actual writer, such as fmt calling the
+// fmt.Scanner returned by Value.Scanner.
+var _writerSkipPkgs = []string{"fmt.", "runtime."}
+
+// skipWriterFrame reports whether the frame of function fn is skipped when recording the last writer of a Value.
+func skipWriterFrame(fn string) bool {
+	if strings.HasPrefix(fn, _valuePkg) {
+		name := fn[len(_valuePkg):]
+		for _, prefix := range _valueFuncs {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, prefix := range _writerSkipPkgs {
+		if strings.HasPrefix(fn, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastWriter returns the location of the code that last wrote to the Value, formatted as file:line, if the Value
+// was created with the RecordLastWriter option. The location is the first caller outside the methods and functions
+// of Value itself, and outside of the standard library code calling its helpers, such as fmt scanning into the
+// fmt.Scanner returned by Scanner. LastWriter returns an empty string if the option was not passed.
+func (v *Value[T]) LastWriter() string {
+	cfg := v.config()
+	if cfg == nil || cfg.lastWriter == nil {
+		return ""
+	}
+	s, _ := cfg.lastWriter.Load().(string)
+	return s
+}
+
+// recordWriter records the location of the caller writing to the Value if cfg, the valueConfig of the Value, has the
+// RecordLastWriter option set. If all callers are skipped, which is the case for writes by a goroutine started by
+// NewFromChannel, the location of the outermost function of this package is recorded instead.
+func (v *Value[T]) recordWriter(cfg *valueConfig[T]) {
+	if cfg.lastWriter == nil {
+		return
+	}
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	var outermost string
+	for {
+		frame, more := frames.Next()
+		location := fmt.Sprintf("%v:%v", frame.File, frame.Line)
+		if !skipWriterFrame(frame.Function) {
+			cfg.lastWriter.Store(location)
+			return
+		}
+		if strings.HasPrefix(frame.Function, _valuePkg) {
+			outermost = location
+		}
+		if !more {
+			if outermost != "" {
+				cfg.lastWriter.Store(outermost)
+			}
+			return
+		}
+	}
+}
+
+// ValueStats holds the number of operations performed on a Value created with the CollectStats option.
+type ValueStats struct {
+	// Stores is the number of values stored by Store and methods built on it.
+	Stores uint64
+	// Swaps is the number of calls to Swap.
+	Swaps uint64
+	// CASAttempts is the number of compare-and-swap operations attempted, both through CompareAndSwap and by
+	// methods that retry a compare-and-swap until it succeeds.
+	CASAttempts uint64
+	// CASFailures is the number of compare-and-swap operations out of CASAttempts that did not swap.
+	CASFailures uint64
+}
+
+// valueStats holds the counters of a Value created with the CollectStats option.
+type valueStats struct {
+	stores, swaps, casAttempts, casFailures Uint64
+}
+
+// Stats returns the number of operations performed on the Value so far. Every counter is loaded atomically, but the
+// counters are not a consistent snapshot if the Value is used concurrently. Stats returns zero counters if the
+// Value was not created with the CollectStats option.
+func (v *Value[T]) Stats() ValueStats {
+	s := v.stats()
+	if s == nil {
+		return ValueStats{}
+	}
+	return ValueStats{
+		Stores:      s.stores.Load(),
+		Swaps:       s.swaps.Load(),
+		CASAttempts: s.casAttempts.Load(),
+		CASFailures: s.casFailures.Load(),
+	}
+}
+
+// stats returns the counters of the Value, or nil if it was not created with the CollectStats option.
+func (v *Value[T]) stats() *valueStats {
+	if cfg := v.config(); cfg != nil {
+		return cfg.stats
+	}
+	return nil
+}
+
+// compareAndSwapRaw executes the compare-and-swap operation on the underlying atomic.Value, counting it if the Value
+// was created with the CollectStats option.
+func (v *Value[T]) compareAndSwapRaw(old any, new wrapper[T]) (swapped bool) {
+	e := v.extension()
+	if e == nil {
+		// A Value without valueExt has no options, hooks or frozen flag, and only needs to notify watchers that might
+		// have been registered concurrently.
+		if swapped = v.Value.CompareAndSwap(old, new); swapped {
+			v.written(old == nil)
+		}
+		return swapped
+	}
+	e.writers.Inc()
+	defer e.writers.Dec()
+	v.checkFrozen()
+	swapped = v.Value.CompareAndSwap(old, new)
+	v.compareAndSwapped(old, swapped)
+	return swapped
+}
+
+// compareAndSwapped must be called after every compare-and-swap operation on the underlying atomic.Value, with the
+// raw old value passed to it and whether it swapped. It counts the operation and, if it swapped, notifies the hooks
+// and watchers of the Value.
+func (v *Value[T]) compareAndSwapped(old any, swapped bool) {
+	if s := v.stats(); s != nil {
+		s.casAttempts.Inc()
+		if !swapped {
+			s.casFailures.Inc()
+		}
+	}
+	if swapped {
+		v.written(old == nil)
+		v.replaced(old)
+	}
+}
+
+// written must be called after every write to the Value. first indicates whether the write may have set the Value
+// for the first time. Every write counts itself in the stats of the Value first, then calls written and calls
+// replaced last, so that the function set through OnReplace observes the same state regardless of the method used.
+func (v *Value[T]) written(first bool) {
+	// The valueExt must be loaded after the write, so that a watcher registered concurrently either loads the new
+	// value in LoadAndWatch or is notified of it.
+	e := v.extension()
+	if e == nil {
+		return
+	}
+	v.recordWriter(&e.cfg)
+	if first {
+		e.ready.signal()
+	}
+	v.notifyWatchers(&e.watchers)
+}
+
+// OnReplace sets a function that is called with the previous value held by the Value every time it is replaced by
+// a successful Store, Swap, CompareAndSwap or other write. This provides a deterministic point to release resources
+// tied to a value that was retired, unlike a finalizer. fn is not called for the first value stored to a Value that
+// was never stored to. fn runs synchronously on the goroutine writing to the Value, after the new value has been
+// stored, counted in Stats and delivered to watchers, whichever method wrote it. OnReplace replaces any function set
+// previously, and passing nil removes it.
+func (v *Value[T]) OnReplace(fn func(old T)) {
+	v.loadOrCreateExtension().onReplace.Store(fn)
+}
+
+// OnCASFailure sets a function that is called every time a compare-and-swap performed internally by a method that
+// retries it until it succeeds, such as UpdateAll, UpdateIfChanged or StoreIfNewer, loses a race with another write.
+// fn is passed the number of the attempt that failed, starting at 1 for every call of such a method. This allows
+// attributing contention to a specific Value. A failed call to CompareAndSwap itself is not reported, as it is not
+// retried. fn runs synchronously on the goroutine retrying. OnCASFailure replaces any function set previously, and
+// passing nil removes it. Values without such a function only check for one after a failed attempt. Note that, like
+// the other hooks, setting a function allocates the optional state of the Value, after which every write to it also
+// checks for hooks and watchers, even if the function is removed again.
+func (v *Value[T]) OnCASFailure(fn func(attempt int)) {
+	v.loadOrCreateExtension().onCASFail.Store(fn)
+}
+
+// casFailed calls the function set through OnCASFailure, if any, with the attempt that failed.
+func (v *Value[T]) casFailed(attempt int) {
+	e := v.extension()
+	if e == nil {
+		return
+	}
+	if fn, _ := e.onCASFail.Load().(func(int)); fn != nil {
+		fn(attempt)
+	}
+}
+
+// replaceHook returns the function set through OnReplace, or nil if none is set.
+func (v *Value[T]) replaceHook() func(T) {
+	e := v.extension()
+	if e == nil {
+		return nil
+	}
+	fn, _ := e.onReplace.Load().(func(T))
+	return fn
+}
+
+// replaced calls the function set through OnReplace, if any, with the value held by raw, unless raw is nil because
+// the Value was not set before.
+func (v *Value[T]) replaced(raw any) {
+	if raw == nil {
+		return
+	}
+	if fn := v.replaceHook(); fn != nil {
+		fn(unwrap[T](raw))
+	}
+}
+
+// readySignal holds the channel returned by Value.Ready, which is closed once.
+type readySignal struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+// signal closes the channel of the readySignal, unless it was already closed.
+func (s *readySignal) signal() {
+	s.once.Do(func() { close(s.ch) })
+}
+
+// Ready returns a channel that is closed once a value is first stored to the Value, by a Store, Swap, CompareAndSwap
+// or any other write. Every call returns the same channel, and if the Value is already set, the channel returned is
+// already closed. Ready may be used to wait until a Value is initialised.
+func (v *Value[T]) Ready() <-chan struct{} {
+	e := v.loadOrCreateExtension()
+	// The Value may have been stored to before the valueExt was allocated, in which case the write did not close the
+	// channel.
+	if v.IsSet() {
+		e.ready.signal()
+	}
+	return e.ready.ch
+}
+
+// pack prepares val for storage in the underlying atomic.Value, cloning it if the Value was created with
+// StoreClones.
+func (v *Value[T]) pack(val T) wrapper[T] {
+	if cfg := v.config(); cfg != nil && cfg.clone != nil {
+		val = cfg.clone(val)
+	}
+	return wrap(val)
+}
+
+// NewZeroValue creates a Value[T] holding the zero value of T. Unlike a Value[T] that was never stored to, such as
+// the zero Value[T], the Value returned reports true from IsSet, and a CompareAndSwap with the zero value of T as old
+// value succeeds on it.
+func NewZeroValue[T any]() *Value[T] {
+	var zero T
+	return NewValue(zero)
+}
+
+// IsSet checks if a value was ever stored to the Value. Load returns the zero value of T both for a Value that was
+// never stored to and for one holding the zero value, but only the latter reports true from IsSet.
+func (v *Value[T]) IsSet() bool {
+	return v.Value.Load() != nil
+}
+
 // Load returns the value set by the most recent Store.
-// It returns nil if there has been no call to Store for this Value.
+// It returns the zero value of T if there has been no call to Store for this Value.
 func (v *Value[T]) Load() (val T) {
 	return unwrap[T](v.Value.Load())
 }
 
-// Store sets the value of the Value to x.
-// All calls to Store for a given Value must use values of the same concrete type.
-// Store of an inconsistent type panics, as does Store(nil).
+// LoadType returns the concrete type of the value currently held, which is mostly useful for a Value[T] where T is
+// an interface type, such as Value[any]. LoadType returns nil if the Value was never stored to or holds a nil
+// interface value. The type returned reflects a single load and may be stale as soon as LoadType returns.
+func (v *Value[T]) LoadType() reflect.Type {
+	raw := v.Value.Load()
+	if raw == nil {
+		return nil
+	}
+	return reflect.TypeOf(raw.(wrapper[T]).val)
+}
+
+// Is checks if the value currently held by v is of type T, or implements T if T is an interface type. Like LoadType,
+// Is reflects a single load and may be stale as soon as it returns. Is returns false if v was never stored to or
+// holds a nil interface value.
+func Is[T, V any](v *Value[V]) bool {
+	_, ok := any(v.Load()).(T)
+	return ok
+}
+
+// LoadOr returns the value set by the most recent Store, or fallback if the Value was never stored to. Unlike
+// storing a default, LoadOr never writes to the Value.
+func (v *Value[T]) LoadOr(fallback T) T {
+	if raw := v.Value.Load(); raw != nil {
+		return raw.(wrapper[T]).val
+	}
+	return fallback
+}
+
+// LoadOrWait returns the value held if the Value was stored to, and otherwise waits for the first write to the Value
+// and returns the value written. If ctx is done before the Value is written to, LoadOrWait returns fallback. This is
+// useful for reading values that may not be ready yet at startup, without blocking indefinitely.
+func (v *Value[T]) LoadOrWait(ctx context.Context, fallback T) T {
+	if raw := v.Value.Load(); raw != nil {
+		return raw.(wrapper[T]).val
+	}
+	select {
+	case <-v.Ready():
+		return v.Load()
+	case <-ctx.Done():
+		return fallback
+	}
+}
+
+// LoadInto copies the value set by the most recent Store into *dst and reports whether a value was set. If the Value
+// was never stored to, *dst is left unchanged. LoadInto still copies the value held once, but allows reusing dst
+// across loads of large values of T.
+func (v *Value[T]) LoadInto(dst *T) (ok bool) {
+	raw := v.Value.Load()
+	if raw == nil {
+		return false
+	}
+	*dst = raw.(wrapper[T]).val
+	return true
+}
+
+// With loads the value currently held once and passes it to fn, so that all fields of the value read by fn belong
+// to the same snapshot, even if the Value is written to concurrently. fn is passed the zero value of T if the Value
+// is empty. The snapshot is shallow: if T holds pointers, slices or maps, the data they refer to is shared with the
+// Value and may still be modified concurrently.
+func (v *Value[T]) With(fn func(val T)) {
+	fn(v.Load())
+}
+
+// Store sets the value of the Value to val. Values of different concrete types may be stored in the same Value if T
+// is an interface type, and storing a nil interface value is permitted.
 func (v *Value[T]) Store(val T) {
-	v.Value.Store(wrap(val))
+	e := v.extension()
+	if e == nil {
+		v.Value.Store(wrap(val))
+		v.written(true)
+		return
+	}
+	e.writers.Inc()
+	defer e.writers.Dec()
+	v.store(val)
+}
+
+// store stores val into the underlying atomic.Value. It must only be called while counted in the writers of the
+// valueExt, if the Value has one.
+func (v *Value[T]) store(val T) {
+	v.checkFrozen()
+	// The previous value is only needed for the function set through OnReplace, so a plain store suffices without
+	// one.
+	var raw any
+	if v.replaceHook() != nil {
+		raw = v.Value.Swap(v.pack(val))
+	} else {
+		v.Value.Store(v.pack(val))
+	}
+	if s := v.stats(); s != nil {
+		s.stores.Inc()
+	}
+	v.written(true)
+	v.replaced(raw)
+}
+
+// ErrFrozen is the error returned by StoreErr and CompareAndSwapErr, and the value other writes panic with, when
+// writing to a Value that was frozen using Freeze.
+var ErrFrozen = errors.New("atomic: write to frozen Value")
+
+// Freeze makes the Value reject all writes from now on, for example to catch code modifying configuration after it
+// was finalised. Store, Swap, CompareAndSwap and all other writes panic with ErrFrozen after Freeze, while StoreErr
+// and CompareAndSwapErr return it instead. Loads keep working. Freezing is one-way: a frozen Value cannot be unfrozen.
+// A write running concurrently with Freeze may still complete, but no write starting after Freeze returns does.
+func (v *Value[T]) Freeze() {
+	v.loadOrCreateExtension().frozen.Store(true)
+}
+
+// IsFrozen reports whether the Value was frozen using Freeze.
+func (v *Value[T]) IsFrozen() bool {
+	e := v.extension()
+	return e != nil && e.frozen.Load()
+}
+
+// checkFrozen panics with ErrFrozen if the Value was frozen. It must be called before every write.
+func (v *Value[T]) checkFrozen() {
+	if v.IsFrozen() {
+		panic(ErrFrozen)
+	}
+}
+
+// StoreErr sets the value of the Value to val like Store, but returns ErrFrozen instead of panicking if the Value was
+// frozen using Freeze.
+func (v *Value[T]) StoreErr(val T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != any(ErrFrozen) {
+				panic(r)
+			}
+			err = ErrFrozen
+		}
+	}()
+	v.Store(val)
+	return nil
 }
 
-// Swap stores new into Value and returns the previous value. It returns nil if
-// the Value is empty.
+// StoreAndCheck stores val like Store and reports whether val is equal to target. This combines publishing a new
+// value with checking if a target state was reached, for example to detect convergence.
 //
-// All calls to Swap for a given Value must use values of the same concrete
-// type. Swap of an inconsistent type panics, as does Swap(nil).
+// StoreAndCheck panics if the values compared are of an uncomparable type. StoreAndCheckFunc may be used for such
+// types instead.
+func (v *Value[T]) StoreAndCheck(val, target T) (reachedTarget bool) {
+	v.Store(val)
+	return any(val) == any(target)
+}
+
+// StoreAndCheckFunc stores val like Store and reports whether eq returns true for val and target. Unlike
+// StoreAndCheck, StoreAndCheckFunc may be used if T is an uncomparable type.
+func (v *Value[T]) StoreAndCheckFunc(val, target T, eq func(a, b T) bool) (reachedTarget bool) {
+	v.Store(val)
+	return eq(val, target)
+}
+
+// InitFrom initialises the Value with the first value out of sources that is available, unless the Value is already
+// set, and returns the value held afterwards. Sources are called in order until one of them returns true, and later
+// sources are not called. The value is only stored if the Value was never stored to, so that it is initialised at
+// most once: if the Value is already set, or set concurrently, InitFrom returns the value held instead without
+// storing. InitFrom returns false without storing if the Value is unset and no source returned a value.
+func (v *Value[T]) InitFrom(sources ...func() (T, bool)) (val T, ok bool) {
+	if raw := v.Value.Load(); raw != nil {
+		return unwrap[T](raw), true
+	}
+	for _, source := range sources {
+		if val, ok = source(); ok {
+			break
+		}
+	}
+	if !ok {
+		return val, false
+	}
+	if !v.compareAndSwapRaw(nil, v.pack(val)) {
+		return v.Load(), true
+	}
+	return val, true
+}
+
+// ResetToDefault stores the default value passed to NewValueWithDefault. The Value remains set afterwards. If the
+// Value was not created using NewValueWithDefault, ResetToDefault stores the zero value of T.
+func (v *Value[T]) ResetToDefault() {
+	var def T
+	if cfg := v.config(); cfg != nil {
+		def = cfg.def
+	}
+	v.Store(def)
+}
+
+// TryStore sets the value of the Value to val unless another write to the Value is in progress, in which case
+// TryStore returns false without storing val. Every write counts, including Store, Swap, CompareAndSwap and the
+// attempts of methods retrying a compare-and-swap, as well as other calls to TryStore. Writes in progress are only
+// tracked once TryStore was first called on the Value, so that other Values do not pay for it, which means that the
+// first call does not observe writes that were already in progress. TryStore is advisory: it only skips writing if a
+// write is in progress at the time of the call, and writes starting while TryStore stores val still proceed. It is
+// meant for producers that would rather skip a redundant update than contend with another producer, and is not a
+// replacement for a mutex.
+func (v *Value[T]) TryStore(val T) (stored bool) {
+	e := v.loadOrCreateExtension()
+	if !e.writers.CAS(0, 1) {
+		return false
+	}
+	defer e.writers.Dec()
+
+	v.store(val)
+	return true
+}
+
+// StoreIfNewer stores val if ts is greater than the timestamp of the value currently held, as returned by tsOf, and
+// reports whether val was stored. This implements last-writer-wins conflict resolution for values carrying an external
+// timestamp: of values stored out of order, only the newest is kept. A Value that was never stored to always accepts
+// val. Like CompareAndSwap, StoreIfNewer panics if T is an uncomparable type, or if the Value holds a value that is
+// not equal to itself, such as NaN.
+func (v *Value[T]) StoreIfNewer(val T, ts int64, tsOf func(T) int64) (stored bool) {
+	for attempt := 1; ; attempt++ {
+		raw := v.Value.Load()
+		if raw != nil && ts <= tsOf(unwrap[T](raw)) {
+			return false
+		}
+		if v.compareAndSwapRaw(raw, v.pack(val)) {
+			return true
+		}
+		checkSelfEqual[T](raw)
+		v.casFailed(attempt)
+	}
+}
+
+// Swap stores new into Value and returns the previous value. It returns the zero
+// value of T if the Value is empty.
 func (v *Value[T]) Swap(new T) (old T) {
-	return unwrap[T](v.Value.Swap(wrap(new)))
+	return unwrap[T](v.swapRaw(new))
+}
+
+// SwapInto stores new into the Value like Swap, but copies the previous value into *old instead of returning it, and
+// reports whether the Value held a previous value. If the Value was empty, *old is left unchanged. Like LoadInto,
+// SwapInto allows reusing old across swaps of large values of T.
+func (v *Value[T]) SwapInto(new T, old *T) (ok bool) {
+	raw := v.swapRaw(new)
+	if raw == nil {
+		return false
+	}
+	*old = raw.(wrapper[T]).val
+	return true
+}
+
+// SwapForCleanup stores new into the Value like Swap and returns the previous value, along with whether the Value
+// held one. It is meant for values owning a resource, such as a file, that must be released once replaced: every
+// value stored is returned by exactly one call to SwapForCleanup or Swap, so the caller may release old, typically
+// using defer. If wasSet is false, the Value was never stored to and old is the zero value of T, which must not be
+// released.
+func (v *Value[T]) SwapForCleanup(new T) (old T, wasSet bool) {
+	raw := v.swapRaw(new)
+	return unwrap[T](raw), raw != nil
+}
+
+// StoreWithHooks stores new into the Value like Swap, and then calls onOld with the value it replaced and onNew with
+// new, in that order. It is meant for hot-swapping values such as handler funcs, where the retired value must be
+// drained or cleaned up and the installed one set up. onOld is only called if the Value held a previous value, so
+// that every value stored is passed to onOld exactly once. Either hook may be nil. The hooks are called after the
+// swap, so new may already be loaded by other goroutines while onOld runs, and the hooks of concurrent calls to
+// StoreWithHooks may interleave.
+func (v *Value[T]) StoreWithHooks(new T, onOld, onNew func(T)) {
+	old, wasSet := v.SwapForCleanup(new)
+	if wasSet && onOld != nil {
+		onOld(old)
+	}
+	if onNew != nil {
+		onNew(new)
+	}
+}
+
+// swapRaw stores new into the underlying atomic.Value and returns the raw value previously held.
+func (v *Value[T]) swapRaw(new T) any {
+	e := v.extension()
+	if e == nil {
+		raw := v.Value.Swap(wrap(new))
+		v.written(raw == nil)
+		return raw
+	}
+	e.writers.Inc()
+	defer e.writers.Dec()
+	v.checkFrozen()
+	raw := v.Value.Swap(v.pack(new))
+	if s := v.stats(); s != nil {
+		s.swaps.Inc()
+	}
+	v.written(raw == nil)
+	v.replaced(raw)
+	return raw
 }
 
 // CompareAndSwap executes the compare-and-swap operation for the Value.
 //
-// All calls to CompareAndSwap for a given Value must use values of the same
-// concrete type. CompareAndSwap of an inconsistent type panics, as does
-// CompareAndSwap(old, nil).
+// CompareAndSwap panics if the values compared are of an uncomparable type.
 func (v *Value[T]) CompareAndSwap(old, new T) (swapped bool) {
-	return v.Value.CompareAndSwap(wrap(old), wrap(new))
+	return v.compareAndSwapRaw(wrap(old), v.pack(new))
+}
+
+// CompareAndSwapOrCurrent executes the compare-and-swap operation for the Value like CompareAndSwap, and returns the
+// value that was observed in the Value. If swapped is false, current is the value that blocked the swap, which makes
+// CompareAndSwapOrCurrent useful for logging unexpected transitions of a state machine. If swapped is true, current is
+// equal to old. The value observed is the one held at the moment the compare-and-swap failed, so it is not equal to old
+// when swapped is false, with one exception: like CompareAndSwap, CompareAndSwapOrCurrent never swaps a Value that was
+// never stored to, for which current is the zero value of T, even if old is the zero value as well. IsSet may be used
+// to tell this case apart. Like CompareAndSwap, CompareAndSwapOrCurrent panics if the values compared are of an
+// uncomparable type.
+func (v *Value[T]) CompareAndSwapOrCurrent(old, new T) (current T, swapped bool) {
+	v.checkFrozen()
+	packed := v.pack(new)
+	for {
+		raw := v.Value.Load()
+		if raw != any(wrap(old)) {
+			return unwrap[T](raw), false
+		}
+		if v.compareAndSwapRaw(raw, packed) {
+			return old, true
+		}
+	}
+}
+
+// _casRetryMaxBackoff is the longest duration CompareAndSwapRetry sleeps for between two attempts.
+const _casRetryMaxBackoff = time.Millisecond
+
+// CompareAndSwapRetry executes the compare-and-swap operation for the Value like CompareAndSwap, retrying it up to
+// maxAttempts times in total if it fails, and reports whether it swapped and how many attempts it took. Between
+// attempts, CompareAndSwapRetry sleeps for an exponentially growing duration, starting at a microsecond and capped at
+// a millisecond, so that a large maxAttempts never results in excessive sleeps. This bounds the effort spent on a
+// contended Value, for callers that would rather give up than spin. At least one attempt is
+// made, even if maxAttempts is less than 1. Like CompareAndSwap, CompareAndSwapRetry panics if the values compared
+// are of an uncomparable type.
+func (v *Value[T]) CompareAndSwapRetry(old, new T, maxAttempts int) (swapped bool, attempts int) {
+	backoff := time.Microsecond
+	for attempts = 1; ; attempts++ {
+		if v.CompareAndSwap(old, new) {
+			return true, attempts
+		}
+		if attempts >= maxAttempts {
+			return false, attempts
+		}
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > _casRetryMaxBackoff {
+			backoff = _casRetryMaxBackoff
+		}
+	}
+}
+
+// update atomically replaces the value held by the result of fn, calling fn again if the Value was modified
+// concurrently. fn is passed the value currently held, or the zero value of T if the Value is empty, and returns the
+// new value and whether it should be stored. update returns the value held after the call and whether it was stored
+// by fn. Like CompareAndSwap, update panics if T is an uncomparable type. update also panics if the Value holds a
+// value that is not equal to itself, such as NaN, as a compare-and-swap from such a value never succeeds.
+func (v *Value[T]) update(fn func(old T) (new T, ok bool)) (T, bool) {
+	for attempt := 1; ; attempt++ {
+		raw := v.Value.Load()
+		old := unwrap[T](raw)
+		new, ok := fn(old)
+		if !ok {
+			return old, false
+		}
+		if v.compareAndSwapRaw(raw, v.pack(new)) {
+			return new, true
+		}
+		checkSelfEqual[T](raw)
+		v.casFailed(attempt)
+	}
+}
+
+// selfUnequalError is the error methods retrying a compare-and-swap panic with if the Value holds a value that is
+// not equal to itself. It implements runtime.Error, like the error raised when comparing uncomparable types.
+type selfUnequalError struct{ t reflect.Type }
+
+// Error returns a message describing the value that could not be swapped from.
+func (e selfUnequalError) Error() string {
+	return fmt.Sprintf("atomic: compare and swap from value of type %v that is not equal to itself, such as NaN", e.t)
+}
+
+// RuntimeError implements runtime.Error.
+func (selfUnequalError) RuntimeError() {}
+
+// checkSelfEqual panics with a selfUnequalError if raw, the raw value held by a Value that a compare-and-swap failed
+// to swap from, is not equal to itself. Retrying the compare-and-swap would then loop forever, as the underlying
+// atomic.Value compares using ==. checkSelfEqual must only be called after a failed compare-and-swap, so that the
+// comparison is not paid for on the fast path.
+func checkSelfEqual[T any](raw any) {
+	if raw != nil && raw != raw {
+		panic(selfUnequalError{t: reflect.TypeOf(raw.(wrapper[T]).val)})
+	}
+}
+
+// UpdateIfChanged atomically replaces the value held by the result of fn, calling fn again if the Value was
+// modified concurrently, but skips the write entirely if fn returns a value equal to the one currently held. It
+// returns the value held after the call and whether it was written. fn is passed the value currently held, or the
+// zero value of T if the Value is empty. Values are compared using ==, so UpdateIfChanged panics if T is an
+// uncomparable type. Like UpdateAll, it also panics if the Value holds a value that is not equal to itself.
+func (v *Value[T]) UpdateIfChanged(fn func(old T) T) (new T, changed bool) {
+	return v.UpdateIfChangedFunc(fn, func(a, b T) bool {
+		return any(a) == any(b)
+	})
+}
+
+// UpdateIfChangedFunc works like UpdateIfChanged, but uses eq to check whether the value returned by fn equals the
+// value currently held, for example to compare the values that two pointers point to. As the write is published
+// using a compare-and-swap, UpdateIfChangedFunc still panics if T is an uncomparable type, such as a slice or map,
+// or if the Value holds a value that is not equal to itself.
+func (v *Value[T]) UpdateIfChangedFunc(fn func(old T) T, eq func(a, b T) bool) (new T, changed bool) {
+	return v.update(func(old T) (T, bool) {
+		new := fn(old)
+		return new, !eq(old, new)
+	})
+}
+
+// UpdateAll atomically replaces the value held by the result of applying fns to it in order, and returns the new
+// value. Only the final result is written: none of the intermediate results are observable by readers, and the
+// functions are applied again from the start if the Value was modified concurrently. Every function must therefore
+// be free of side effects. fns are passed the zero value of T first if the Value is empty. Like CompareAndSwap,
+// UpdateAll panics if T is an uncomparable type. UpdateAll also panics if the Value holds a value that is not equal
+// to itself, such as NaN, instead of retrying forever, as a compare-and-swap from such a value never succeeds.
+func (v *Value[T]) UpdateAll(fns ...func(T) T) (new T) {
+	new, _ = v.update(func(old T) (T, bool) {
+		for _, fn := range fns {
+			old = fn(old)
+		}
+		return old, true
+	})
+	return new
+}
+
+// CompareAndSwapErr executes the compare-and-swap operation for the Value like CompareAndSwap, but returns an error
+// instead of panicking if the values compared are of an uncomparable type, such as a slice or map held by a
+// Value[any], or ErrFrozen if the Value was frozen. A value currently held that is of a different concrete type than
+// old, or that is of the same type but not equal to old, results in a normal false without error. Only the
+// comparison is recovered from: a panic raised by a function set through OnReplace after the swap propagates.
+func (v *Value[T]) CompareAndSwapErr(old, new T) (swapped bool, err error) {
+	if v.IsFrozen() {
+		return false, ErrFrozen
+	}
+	packed := v.pack(new)
+
+	if e := v.extension(); e != nil {
+		e.writers.Inc()
+		defer e.writers.Dec()
+	}
+	if swapped, err = v.tryCompareAndSwap(wrap(old), packed); err != nil {
+		return false, err
+	}
+	// The hooks and watchers are notified outside of tryCompareAndSwap, so that a panic raised by them propagates
+	// instead of being reported as a failed swap.
+	v.compareAndSwapped(wrap(old), swapped)
+	return swapped, nil
+}
+
+// tryCompareAndSwap executes the compare-and-swap operation on the underlying atomic.Value, returning an error
+// instead of panicking if the values compared are of an uncomparable type.
+func (v *Value[T]) tryCompareAndSwap(old any, new wrapper[T]) (swapped bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			rerr, ok := r.(runtime.Error)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("atomic: compare and swap: %w", rerr)
+		}
+	}()
+	return v.Value.CompareAndSwap(old, new), nil
+}
+
+// CloneFunc loads the value currently held and returns a new Value holding the result of passing that value to
+// copy. copy must return a value fully independent of the one passed, for example by deep copying any slices, maps
+// or pointers it holds, so that neither Value observes modifications made through the other.
+func (v *Value[T]) CloneFunc(copy func(T) T) *Value[T] {
+	return NewValue(copy(v.Load()))
 }
 
-// String implements fmt.Stringer to return the standard value representation of the underlying value.
+// ExportState returns the opaque internal state of the Value, which may be passed to ImportState of another Value of
+// the same type T to make it hold the same value without copying or serialising it. ExportState returns nil if the
+// Value is empty. The state is shared between all Values it is imported into: like any value of T stored into two
+// Values, a value holding pointers, slices or maps is not copied.
+func (v *Value[T]) ExportState() any {
+	return v.Value.Load()
+}
+
+// ImportState atomically replaces the internal state of the Value by state, which must have been returned by
+// ExportState of a Value of the same type T. Importing counts as a store, so that hooks and watchers registered on
+// the Value observe it, but the option set using StoreClones is not applied. ImportState returns an error if state
+// was not exported by a Value[T], or if state is nil, the state of an empty Value, while the Value is set, as a
+// Value can never be made empty again. ImportState returns ErrFrozen if the Value was frozen.
+func (v *Value[T]) ImportState(state any) error {
+	if v.IsFrozen() {
+		return ErrFrozen
+	}
+	if state == nil {
+		if v.IsSet() {
+			return fmt.Errorf("atomic: cannot import empty state into Value[%v]: Value is already set", typeOf[T]())
+		}
+		return nil
+	}
+	w, ok := state.(wrapper[T])
+	if !ok {
+		return fmt.Errorf("atomic: cannot import state into Value[%v]: state was not exported by a Value[%[1]v]", typeOf[T]())
+	}
+	if e := v.extension(); e != nil {
+		e.writers.Inc()
+		defer e.writers.Dec()
+	}
+	raw := v.Value.Swap(w)
+	if s := v.stats(); s != nil {
+		s.stores.Inc()
+	}
+	v.written(true)
+	v.replaced(raw)
+	return nil
+}
+
+// Fingerprint loads the value currently held once and returns the result of passing it to hash. Comparing
+// fingerprints allows detecting changes to the content of the value without keeping a copy of it, as long as hash
+// returns equal fingerprints for equal content.
+func (v *Value[T]) Fingerprint(hash func(T) uint64) uint64 {
+	return hash(v.Load())
+}
+
+// FingerprintChanged computes the Fingerprint of the value currently held and reports whether it differs from prev,
+// typically a fingerprint returned by an earlier call. The new fingerprint is returned, so that it may be passed as
+// prev to the next call.
+func (v *Value[T]) FingerprintChanged(prev uint64, hash func(T) uint64) (fingerprint uint64, changed bool) {
+	fingerprint = v.Fingerprint(hash)
+	return fingerprint, fingerprint != prev
+}
+
+// Reader is implemented by types that allow reading, but not writing, a value of type T.
+type Reader[T any] interface {
+	// Load returns the value currently held.
+	Load() T
+	// IsSet checks if a value was ever stored.
+	IsSet() bool
+}
+
+// ReadOnly returns a Reader of the Value. Values stored to the Value are visible through the Reader, but the Reader
+// can not be used or converted back to write to the Value, which makes it suitable to pass to code that should only
+// read it.
+func (v *Value[T]) ReadOnly() Reader[T] {
+	return readOnlyValue[T]{v: v}
+}
+
+// readOnlyValue implements Reader by wrapping a *Value[T] without exposing its other methods.
+type readOnlyValue[T any] struct{ v *Value[T] }
+
+// Load returns the value currently held by the Value.
+func (r readOnlyValue[T]) Load() T { return r.v.Load() }
+
+// IsSet checks if a value was ever stored to the Value.
+func (r readOnlyValue[T]) IsSet() bool { return r.v.IsSet() }
+
+// SetStringer sets a function used by String and GoString to format the underlying value, for example to redact
+// secrets held by the Value so that they do not end up in logs. Passing nil restores the default formatting.
+func (v *Value[T]) SetStringer(fn func(T) string) {
+	v.loadOrCreateExtension().stringer.Store(fn)
+}
+
+// format formats the underlying value using the function set through SetStringer and reports if one was set.
+func (v *Value[T]) format() (string, bool) {
+	e := v.extension()
+	if e == nil {
+		return "", false
+	}
+	if fn, _ := e.stringer.Load().(func(T) string); fn != nil {
+		return fn(v.Load()), true
+	}
+	return "", false
+}
+
+// String implements fmt.Stringer to return the standard value representation of the underlying value, or the result
+// of the function set through SetStringer.
 func (v *Value[T]) String() string {
+	if s, ok := v.format(); ok {
+		return s
+	}
 	return fmt.Sprint(v.Load())
 }
 
-// GoString implements fmt.GoStringer to return a valid Go syntax representation of the underlying value.
+// GoString implements fmt.GoStringer to return a valid Go syntax representation of the underlying value, or the
+// result of the function set through SetStringer.
 func (v *Value[T]) GoString() string {
+	if s, ok := v.format(); ok {
+		return s
+	}
 	return fmt.Sprintf("%#v", v.Load())
 }
+
+// binaryAppender is implemented by types that append their binary encoding to a byte slice. It matches the
+// encoding.BinaryAppender interface added in Go 1.24.
+type binaryAppender interface {
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// AppendBinary loads the value currently held and appends its binary encoding to b. If T implements the
+// AppendBinary method of encoding.BinaryAppender, that method is used, so that the encoding may reuse the capacity
+// of b. Otherwise, T must implement encoding.BinaryMarshaler, and the result of its MarshalBinary method is appended.
+func (v *Value[T]) AppendBinary(b []byte) ([]byte, error) {
+	switch val := any(v.Load()).(type) {
+	case binaryAppender:
+		return val.AppendBinary(b)
+	case encoding.BinaryMarshaler:
+		data, err := val.MarshalBinary()
+		if err != nil {
+			return b, err
+		}
+		return append(b, data...), nil
+	default:
+		return b, fmt.Errorf("atomic: cannot append binary encoding of Value[%[1]v]: %[1]v implements neither AppendBinary nor encoding.BinaryMarshaler", typeOf[T]())
+	}
+}
+
+// Scanner returns a fmt.Scanner for the Value, so that values may be parsed into it using fmt.Sscan and related
+// functions. The Scan method of the fmt.Scanner returned scans into a new value of type T and atomically stores it
+// if scanning succeeds. If *T implements fmt.Scanner, its Scan method is used. Otherwise, T must be a boolean,
+// numeric or string type, which is scanned from the next space-delimited token like fmt.Sscan would. Value itself
+// cannot implement fmt.Scanner, as its Scan method implements sql.Scanner.
+func (v *Value[T]) Scanner() fmt.Scanner {
+	return fmtScanner[T]{v: v}
+}
+
+// fmtScanner implements fmt.Scanner for a *Value[T].
+type fmtScanner[T any] struct{ v *Value[T] }
+
+// Scan scans a new value of type T from state and stores it in the Value.
+func (s fmtScanner[T]) Scan(state fmt.ScanState, verb rune) error {
+	var val T
+	if sc, ok := any(&val).(fmt.Scanner); ok {
+		if err := sc.Scan(state, verb); err != nil {
+			return err
+		}
+		s.v.Store(val)
+		return nil
+	}
+
+	switch typeOf[T]().Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+	default:
+		return fmt.Errorf("atomic: cannot scan into Value[%[1]v]: %[1]v is not a basic type and *%[1]v does not implement fmt.Scanner", typeOf[T]())
+	}
+	tok, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Sscan(string(tok), &val); err != nil {
+		return fmt.Errorf("atomic: scan into Value[%v]: %w", typeOf[T](), err)
+	}
+	s.v.Store(val)
+	return nil
+}
+
+// MaxValue returns the Value out of vs whose value has the greatest key, as returned by key. Each Value is loaded
+// exactly once, so the key of every Value is computed from a snapshot of that Value alone: the selection is not
+// atomic across the Values passed, and a Value other than the one returned may hold the greatest key after MaxValue
+// returns. If multiple Values have the greatest key, the first of them is returned. MaxValue returns nil if no Values
+// are passed.
+func MaxValue[T any](key func(T) int64, vs ...*Value[T]) *Value[T] {
+	var (
+		max    *Value[T]
+		maxKey int64
+	)
+	for _, v := range vs {
+		if k := key(v.Load()); max == nil || k > maxKey {
+			max, maxKey = v, k
+		}
+	}
+	return max
+}
+
+// UpdateEmit atomically replaces the value held by v with the next value returned by fn, calling fn again if v was
+// modified concurrently, and returns the out value returned by the call to fn whose next value was stored. This
+// allows advancing a state and deciding on an action to take in a single atomic step. fn is passed the value
+// currently held, or the zero value of T if v is empty, and may run multiple times: only the out value of the run
+// that was committed is returned. UpdateEmit is a function rather than a method of Value, as methods cannot have
+// type parameters. Like UpdateAll, UpdateEmit panics if T is an uncomparable type or if v holds a value that is not
+// equal to itself.
+func UpdateEmit[T, R any](v *Value[T], fn func(old T) (next T, out R)) (out R) {
+	v.update(func(old T) (T, bool) {
+		var next T
+		next, out = fn(old)
+		return next, true
+	})
+	return out
+}
+
+// StoreIfNewPointer stores p into v unless v already holds the identical pointer p, and reports whether p was stored.
+// Skipping the store also skips its side effects, such as calling the OnReplace hook, notifying watchers registered
+// using LoadAndWatch and counting the store in Stats, which makes StoreIfNewPointer suitable for republishing large
+// immutable values that may not have changed. Pointers are compared by identity, never by the values they point to.
+// Storing nil into a v that was never stored to is skipped as well, as Load returns nil for it already.
+func StoreIfNewPointer[T any](v *Value[*T], p *T) (stored bool) {
+	_, stored = v.UpdateIfChanged(func(*T) *T { return p })
+	return stored
+}
+
+// Transfer moves the value held by from into to, leaving from holding the zero value of T, and reports whether a
+// value was moved. Transfer returns false without modifying either Value if from was never stored to. A Value cannot
+// be reset to being unset, so a from that was transferred from before moves the zero value of T on the next Transfer.
+//
+// Transfer is not atomic across both Values: the value is first swapped out of from and then stored into to. Between
+// the two, readers of from and to observe neither holding the value, but it is never lost or held by both, as long
+// as from and to are not written to by other goroutines concurrently. Any value held by to before is replaced.
+func Transfer[T any](from, to *Value[T]) (moved bool) {
+	if !from.IsSet() {
+		return false
+	}
+	var zero T
+	to.Store(from.Swap(zero))
+	return true
+}
+
+// LoadAll loads each of the Values passed once and returns their values in the same order. The loads are
+// independent of each other: the slice returned is not a consistent snapshot across the Values if they are modified
+// concurrently.
+func LoadAll[T any](vs ...*Value[T]) []T {
+	vals := make([]T, len(vs))
+	for i, v := range vs {
+		vals[i] = v.Load()
+	}
+	return vals
+}
+
+// LoadAt loads the Value at index i of s. LoadAt panics with a descriptive message if i is out of range.
+func LoadAt[T any](s []*Value[T], i int) T {
+	return valueAt(s, i).Load()
+}
+
+// StoreAt stores val to the Value at index i of s. StoreAt panics with a descriptive message if i is out of range.
+func StoreAt[T any](s []*Value[T], i int, val T) {
+	valueAt(s, i).Store(val)
+}
+
+// CompareAndSwapAt executes the compare-and-swap operation for the Value at index i of s. CompareAndSwapAt panics
+// with a descriptive message if i is out of range.
+func CompareAndSwapAt[T any](s []*Value[T], i int, old, new T) (swapped bool) {
+	return valueAt(s, i).CompareAndSwap(old, new)
+}
+
+// valueAt returns the Value at index i of s, panicking if i is out of range.
+func valueAt[T any](s []*Value[T], i int) *Value[T] {
+	if i < 0 || i >= len(s) {
+		panic(fmt.Sprintf("atomic: index %v out of range for %v Values", i, len(s)))
+	}
+	return s[i]
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "context"
+
+// contextKey is the key under which WithValue stores a *Value[T] in a context. Being generic, it is distinct for every
+// T, so that Values of different types may be carried by the same context.
+type contextKey[T any] struct{}
+
+// WithValue returns a copy of ctx carrying v, which may be retrieved using FromContext[T]. This allows passing a Value
+// shared by a chain of calls, which may load and modify it, through a context. A context carries at most one
+// *Value[T] for every T: WithValue replaces a *Value[T] carried by ctx already in the context returned.
+func WithValue[T any](ctx context.Context, v *Value[T]) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, v)
+}
+
+// FromContext returns the *Value[T] carried by ctx, as added using WithValue, and reports whether ctx carries one.
+func FromContext[T any](ctx context.Context) (v *Value[T], ok bool) {
+	v, ok = ctx.Value(contextKey[T]{}).(*Value[T])
+	return v, ok
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := FromContext[int](ctx)
+	assert.False(t, ok, "FromContext of a context without Value reported one.")
+
+	v := NewValue(1)
+	ctx = WithValue(ctx, v)
+	loaded, ok := FromContext[int](ctx)
+	assert.True(t, ok, "FromContext didn't find the Value added.")
+	assert.True(t, loaded == v, "FromContext didn't return the Value added.")
+
+	_, ok = FromContext[string](ctx)
+	assert.False(t, ok, "FromContext returned a Value of a different type.")
+
+	s := NewValue("foo")
+	ctx = WithValue(ctx, s)
+	loadedString, _ := FromContext[string](ctx)
+	loaded, _ = FromContext[int](ctx)
+	assert.True(t, loadedString == s && loaded == v, "context didn't carry Values of both types.")
+
+	loaded.Store(2)
+	assert.Equal(t, 2, v.Load(), "Value from the context wasn't shared.")
+}
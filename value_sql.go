@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+var _ sql.Scanner = (*Value[int])(nil)
+
+// Scan implements sql.Scanner by scanning src into a new value of type T and atomically storing it. *T must
+// implement sql.Scanner for Scan to succeed. The value held is left unchanged if scanning fails.
+func (v *Value[T]) Scan(src any) error {
+	var val T
+	s, ok := any(&val).(sql.Scanner)
+	if !ok {
+		return fmt.Errorf("atomic: cannot scan into Value[%[1]v]: *%[1]v does not implement sql.Scanner", typeOf[T]())
+	}
+	if err := s.Scan(src); err != nil {
+		return err
+	}
+	v.Store(val)
+	return nil
+}
+
+// Valuer returns a driver.Valuer for the Value. The Value method of the driver.Valuer returned loads the value
+// currently held and delegates to its Value method, which means T must implement driver.Valuer. Value itself cannot
+// implement driver.Valuer, as its Value method would conflict with the embedded atomic.Value.
+func (v *Value[T]) Valuer() driver.Valuer {
+	return valuer[T]{v: v}
+}
+
+// valuer implements driver.Valuer for a *Value[T].
+type valuer[T any] struct{ v *Value[T] }
+
+// Value loads the value held by the Value and returns the result of its Value method.
+func (val valuer[T]) Value() (driver.Value, error) {
+	x, ok := any(val.v.Load()).(driver.Valuer)
+	if !ok {
+		return nil, fmt.Errorf("atomic: cannot get driver value of Value[%v]: type does not implement driver.Valuer", typeOf[T]())
+	}
+	return x.Value()
+}
+
+// typeOf returns the reflect.Type of T, which is also valid if T is an interface type.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// celsius is a temperature implementing sql.Scanner and driver.Valuer.
+type celsius struct{ deg int64 }
+
+func (c *celsius) Scan(src any) error {
+	deg, ok := src.(int64)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into celsius", src)
+	}
+	c.deg = deg
+	return nil
+}
+
+func (c celsius) Value() (driver.Value, error) {
+	return c.deg, nil
+}
+
+func TestValueScan(t *testing.T) {
+	var v Value[celsius]
+	require.NoError(t, v.Scan(int64(21)), "Scan errored unexpectedly.")
+	require.Equal(t, celsius{deg: 21}, v.Load(), "Scan didn't store the scanned value.")
+
+	require.Error(t, v.Scan("21"), "Scan of an unsupported source didn't error.")
+	require.Equal(t, celsius{deg: 21}, v.Load(), "Scan modified the value on error.")
+
+	t.Run("unsupported", func(t *testing.T) {
+		var v Value[int]
+		err := v.Scan(int64(21))
+		require.Error(t, err, "Scan into a type not implementing sql.Scanner didn't error.")
+		assert.Contains(t, err.Error(), "*int does not implement sql.Scanner", "Scan returned an unclear error.")
+	})
+}
+
+func TestValueValuer(t *testing.T) {
+	v := NewValue(celsius{deg: 21})
+	dv, err := v.Valuer().Value()
+	require.NoError(t, err, "Value errored unexpectedly.")
+	assert.Equal(t, int64(21), dv, "Value didn't return the driver value of the value held.")
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewValue(21).Valuer().Value()
+		assert.Error(t, err, "Value of a type not implementing driver.Valuer didn't error.")
+	})
+}
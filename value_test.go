@@ -21,9 +21,20 @@
 package atomic
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValue(t *testing.T) {
@@ -36,5 +47,1177 @@ func TestValue(t *testing.T) {
 	v.Store(84)
 	assert.Equal(t, 84, v.Load())
 
-	assert.Panics(t, func() { v.Store("foo") })
+	assert.NotPanics(t, func() { v.Store("foo") })
+	assert.Equal(t, "foo", v.Load())
+}
+
+func TestValueHeterogeneous(t *testing.T) {
+	type point struct{ X, Y int }
+
+	var v Value[any]
+	v.Store(42)
+	assert.Equal(t, 42, v.Load(), "Load didn't return the stored int.")
+
+	v.Store("foo")
+	assert.Equal(t, "foo", v.Load(), "Load didn't return the stored string.")
+
+	v.Store(point{X: 1, Y: 2})
+	assert.Equal(t, point{X: 1, Y: 2}, v.Load(), "Load didn't return the stored struct.")
+
+	assert.Equal(t, point{X: 1, Y: 2}, v.Swap(nil), "Swap didn't return the old value.")
+	assert.Nil(t, v.Load(), "Load didn't return the stored nil interface.")
+
+	assert.True(t, v.CompareAndSwap(nil, 3.5), "CompareAndSwap didn't swap a nil interface.")
+	assert.False(t, v.CompareAndSwap("foo", 1), "CompareAndSwap swapped a value of a different type.")
+	assert.Equal(t, 3.5, v.Load(), "CompareAndSwap didn't set the correct value.")
+}
+
+func TestValueCloneFunc(t *testing.T) {
+	type config struct {
+		Tags    []string
+		Weights map[string]int
+	}
+	deepCopy := func(c config) config {
+		cp := config{Tags: append([]string(nil), c.Tags...), Weights: make(map[string]int, len(c.Weights))}
+		for k, w := range c.Weights {
+			cp.Weights[k] = w
+		}
+		return cp
+	}
+
+	v := NewValue(config{Tags: []string{"a"}, Weights: map[string]int{"a": 1}})
+	clone := v.CloneFunc(deepCopy)
+	assert.Equal(t, v.Load(), clone.Load(), "CloneFunc didn't copy the value held.")
+
+	c := clone.Load()
+	c.Tags[0] = "b"
+	c.Weights["a"] = 2
+	assert.Equal(t, config{Tags: []string{"a"}, Weights: map[string]int{"a": 1}}, v.Load(),
+		"Modifying the clone affected the original Value.")
+}
+
+func TestMaxValue(t *testing.T) {
+	length := func(s string) int64 { return int64(len(s)) }
+
+	a, b, c := NewValue("a"), NewValue("ccc"), NewValue("bbb")
+	assert.True(t, MaxValue(length, a, b, c) == b, "MaxValue didn't return the Value with the greatest key.")
+	assert.True(t, MaxValue(length, a) == a, "MaxValue didn't return the only Value passed.")
+	assert.Nil(t, MaxValue(length), "MaxValue didn't return nil without Values.")
+
+	a.Store("dddd")
+	assert.True(t, MaxValue(length, a, b, c) == a, "MaxValue didn't observe the Value stored.")
+}
+
+func TestValueSize(t *testing.T) {
+	// Options, hooks, watchers and other rarely used state live in a valueExt allocated on demand, so that containers
+	// of many Values, such as Set or AtomicMap, do not pay for them.
+	var v Value[int]
+	assert.Equal(t, unsafe.Sizeof(v.Value)+unsafe.Sizeof(v.ext), unsafe.Sizeof(v), "Value grew beyond an atomic.Value and a pointer.")
+
+	assert.Nil(t, NewValue(1).extension(), "NewValue without options allocated a valueExt.")
+	assert.NotNil(t, NewValue(1, CollectStats[int]()).extension(), "NewValue didn't keep its options in a valueExt.")
+
+	v.Store(1)
+	v.CompareAndSwap(1, 2)
+	v.Swap(3)
+	assert.Nil(t, v.extension(), "plain writes allocated a valueExt.")
+	v.OnReplace(nil)
+	assert.NotNil(t, v.extension(), "OnReplace didn't allocate a valueExt.")
+}
+
+func TestValueTryStore(t *testing.T) {
+	v := NewValue(1)
+	require.True(t, v.TryStore(2), "TryStore didn't store without contention.")
+	require.Equal(t, 2, v.Load(), "TryStore didn't set the correct value.")
+
+	e := v.extension()
+	require.NotNil(t, e, "TryStore didn't allocate a valueExt to track writes in.")
+	e.writers.Inc()
+	require.False(t, v.TryStore(3), "TryStore stored while another write was in progress.")
+	require.Equal(t, 2, v.Load(), "TryStore modified the value while reporting it didn't.")
+	e.writers.Dec()
+
+	t.Run("store in progress", func(t *testing.T) {
+		var (
+			v       = NewValue(1)
+			entered = make(chan struct{})
+			release = make(chan struct{})
+			done    = make(chan struct{})
+		)
+		v.OnReplace(func(int) {
+			close(entered)
+			<-release
+		})
+		go func() {
+			defer close(done)
+			v.Store(2)
+		}()
+		<-entered
+		assert.False(t, v.TryStore(3), "TryStore stored while a Store was in progress.")
+		close(release)
+		<-done
+
+		v.OnReplace(nil)
+		assert.True(t, v.TryStore(3), "TryStore didn't store after the Store completed.")
+		assert.Equal(t, 3, v.Load(), "TryStore didn't set the correct value.")
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+
+		var (
+			v      Value[int]
+			stored Int32
+			wg     sync.WaitGroup
+		)
+		wg.Add(goroutines)
+		for i := 1; i <= goroutines; i++ {
+			i := i
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if v.TryStore(i) {
+						stored.Inc()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.NotZero(t, stored.Load(), "No TryStore succeeded.")
+		assert.Zero(t, v.extension().writers.Load(), "TryStore didn't release the writer count.")
+		assert.True(t, v.Load() >= 1 && v.Load() <= goroutines, "TryStore stored an unexpected value.")
+	})
+}
+
+func TestNewZeroValue(t *testing.T) {
+	var bare Value[int]
+	assert.False(t, bare.IsSet(), "IsSet of a Value that was never stored to returned true.")
+	assert.False(t, bare.CompareAndSwap(0, 1), "CompareAndSwap of an empty Value swapped.")
+
+	v := NewZeroValue[int]()
+	assert.True(t, v.IsSet(), "IsSet of a zero Value returned false.")
+	assert.Equal(t, 0, v.Load(), "NewZeroValue didn't store the zero value.")
+	assert.True(t, v.CompareAndSwap(0, 1), "CompareAndSwap of a zero Value didn't swap.")
+	assert.Equal(t, 1, v.Load(), "CompareAndSwap didn't set the correct value.")
+
+	t.Run("interface", func(t *testing.T) {
+		v := NewZeroValue[error]()
+		assert.True(t, v.IsSet(), "IsSet of a zero Value returned false.")
+		assert.Nil(t, v.Load(), "NewZeroValue didn't store a nil interface.")
+	})
+}
+
+func TestValueReadOnly(t *testing.T) {
+	var v Value[string]
+	r := v.ReadOnly()
+	assert.False(t, r.IsSet(), "IsSet of the Reader returned true before a Store.")
+
+	v.Store("foo")
+	assert.True(t, r.IsSet(), "IsSet of the Reader returned false after a Store.")
+	assert.Equal(t, "foo", r.Load(), "Load of the Reader didn't return the stored value.")
+
+	_, ok := r.(interface{ Store(string) })
+	assert.False(t, ok, "Reader can be used to store values.")
+}
+
+func TestValueCompareAndSwapErr(t *testing.T) {
+	v := NewValue[any](1)
+	swapped, err := v.CompareAndSwapErr(1, 2)
+	require.NoError(t, err, "CompareAndSwapErr of comparable values errored.")
+	require.True(t, swapped, "CompareAndSwapErr didn't swap.")
+
+	swapped, err = v.CompareAndSwapErr("foo", 3)
+	require.NoError(t, err, "CompareAndSwapErr of values of different types errored.")
+	require.False(t, swapped, "CompareAndSwapErr swapped values of different types.")
+
+	v.Store([]int{1})
+	swapped, err = v.CompareAndSwapErr([]int{1}, 4)
+	require.Error(t, err, "CompareAndSwapErr of uncomparable values didn't error.")
+	assertErrorAsType(t, err, new(runtime.Error), "CompareAndSwapErr failed with unexpected error %v, want runtime.Error.", err)
+	require.False(t, swapped, "CompareAndSwapErr reported a swap on error.")
+	require.Equal(t, []int{1}, v.Load(), "CompareAndSwapErr modified the value on error.")
+}
+
+func TestValueCompareAndSwapErrHookPanic(t *testing.T) {
+	v := NewValue(1)
+	v.OnReplace(func(int) {
+		var m map[string]int
+		m["foo"] = 1
+	})
+
+	var (
+		swapped bool
+		err     error
+	)
+	require.Panics(t, func() { swapped, err = v.CompareAndSwapErr(1, 2) }, "panic of the OnReplace hook didn't propagate.")
+	assert.False(t, swapped, "CompareAndSwapErr returned despite the hook panicking.")
+	assert.NoError(t, err, "panic of the OnReplace hook was reported as an error.")
+	assert.Equal(t, 2, v.Load(), "CompareAndSwapErr didn't swap before the hook panicked.")
+}
+
+func TestValueUpdateSelfUnequal(t *testing.T) {
+	v := NewValue(math.NaN())
+	assertSelfUnequalPanic := func(fn func(), msg string) {
+		defer func() {
+			r := recover()
+			require.NotNil(t, r, msg)
+			_, ok := r.(runtime.Error)
+			assert.True(t, ok, "panic %v of a Value holding NaN isn't a runtime.Error.", r)
+		}()
+		fn()
+	}
+	assertSelfUnequalPanic(func() { v.UpdateAll(func(f float64) float64 { return 1 }) }, "UpdateAll of a Value holding NaN didn't panic.")
+	assertSelfUnequalPanic(func() { v.UpdateIfChanged(func(f float64) float64 { return 1 }) }, "UpdateIfChanged of a Value holding NaN didn't panic.")
+	assertSelfUnequalPanic(func() {
+		v.StoreIfNewer(1, 1, func(float64) int64 { return 0 })
+	}, "StoreIfNewer of a Value holding NaN didn't panic.")
+	assert.True(t, math.IsNaN(v.Load()), "failed update modified the Value.")
+
+	v.Store(1)
+	assert.Equal(t, 2.0, v.UpdateAll(func(f float64) float64 { return f + 1 }), "UpdateAll of a Value holding a number failed.")
+}
+
+func TestValueSetStringer(t *testing.T) {
+	v := NewValue("hunter2")
+	assert.Equal(t, "hunter2", v.String(), "String didn't use the default formatting.")
+	assert.Equal(t, `"hunter2"`, v.GoString(), "GoString didn't use the default formatting.")
+
+	v.SetStringer(func(string) string { return "[redacted]" })
+	assert.Equal(t, "[redacted]", v.String(), "String didn't use the stringer set.")
+	assert.Equal(t, "[redacted]", v.GoString(), "GoString didn't use the stringer set.")
+	assert.Equal(t, "[redacted] [redacted]", fmt.Sprintf("%v %#v", v, v), "fmt didn't use the stringer set.")
+
+	v.SetStringer(nil)
+	assert.Equal(t, "hunter2", v.String(), "String didn't restore the default formatting.")
+}
+
+func TestValueLoadInto(t *testing.T) {
+	var v Value[string]
+	dst := "foo"
+	assert.False(t, v.LoadInto(&dst), "LoadInto of an empty Value reported a value.")
+	assert.Equal(t, "foo", dst, "LoadInto of an empty Value modified dst.")
+
+	v.Store("bar")
+	assert.True(t, v.LoadInto(&dst), "LoadInto didn't report a value.")
+	assert.Equal(t, "bar", dst, "LoadInto didn't copy the value held.")
+}
+
+// largeValue is a value of a type large enough for copying it to be measurable.
+type largeValue struct{ buf [4096]byte }
+
+// _sinkLargeValue is assigned to by benchmarks to prevent loads from being optimised away.
+var _sinkLargeValue largeValue
+
+func BenchmarkValueLoadInto(b *testing.B) {
+	v := NewValue(largeValue{})
+
+	b.Run("Load", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_sinkLargeValue = v.Load()
+		}
+	})
+
+	b.Run("LoadInto", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v.LoadInto(&_sinkLargeValue)
+		}
+	})
+}
+
+func TestValueSwapInto(t *testing.T) {
+	var v Value[string]
+	old := "foo"
+	assert.False(t, v.SwapInto("bar", &old), "SwapInto of an empty Value reported a previous value.")
+	assert.Equal(t, "foo", old, "SwapInto of an empty Value modified old.")
+
+	assert.True(t, v.SwapInto("baz", &old), "SwapInto didn't report a previous value.")
+	assert.Equal(t, "bar", old, "SwapInto didn't copy the previous value.")
+	assert.Equal(t, "baz", v.Load(), "SwapInto didn't store the new value.")
+}
+
+func BenchmarkValueSwapInto(b *testing.B) {
+	v := NewValue(largeValue{})
+	new := largeValue{}
+
+	b.Run("Swap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_sinkLargeValue = v.Swap(new)
+		}
+	})
+
+	b.Run("SwapInto", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			v.SwapInto(new, &_sinkLargeValue)
+		}
+	})
+}
+
+func TestValueStoreClones(t *testing.T) {
+	clone := func(b []byte) []byte { return append([]byte(nil), b...) }
+
+	b := []byte("foo")
+	v := NewValue(b, StoreClones(clone))
+	b[0] = 'g'
+	assert.Equal(t, []byte("foo"), v.Load(), "Modifying the value passed to NewValue affected the Value.")
+
+	b = []byte("bar")
+	v.Store(b)
+	b[0] = 'c'
+	assert.Equal(t, []byte("bar"), v.Load(), "Modifying the value passed to Store affected the Value.")
+
+	b = []byte("baz")
+	v.Swap(b)
+	b[0] = 'c'
+	assert.Equal(t, []byte("baz"), v.Load(), "Modifying the value passed to Swap affected the Value.")
+}
+
+func TestNewValueWithDefaultOptions(t *testing.T) {
+	opts := make([]ValueOption[string], 1, 2)
+	opts[0] = CollectStats[string]()
+	v := NewValueWithDefault("default", opts...)
+	assert.Nil(t, opts[:2][1], "NewValueWithDefault wrote to the backing array of the options passed.")
+
+	w := NewValueWithDefault("other", opts...)
+	v.Store("custom")
+	v.ResetToDefault()
+	w.ResetToDefault()
+	assert.Equal(t, "default", v.Load(), "NewValueWithDefault shared the default between Values.")
+	assert.Equal(t, "other", w.Load(), "NewValueWithDefault shared the default between Values.")
+}
+
+func TestValueResetToDefault(t *testing.T) {
+	v := NewValueWithDefault("default")
+	assert.Equal(t, "default", v.Load(), "NewValueWithDefault didn't store the default.")
+
+	v.Store("custom")
+	v.ResetToDefault()
+	assert.Equal(t, "default", v.Load(), "ResetToDefault didn't restore the default.")
+	assert.True(t, v.IsSet(), "IsSet returned false after ResetToDefault.")
+
+	t.Run("without default", func(t *testing.T) {
+		v := NewValue("custom")
+		v.ResetToDefault()
+		assert.Equal(t, "", v.Load(), "ResetToDefault didn't store the zero value.")
+		assert.True(t, v.IsSet(), "IsSet returned false after ResetToDefault.")
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		def := []int{1, 2}
+		v := NewValueWithDefault(def, StoreClones(func(s []int) []int { return append([]int(nil), s...) }))
+		v.Load()[0] = 3
+		def[1] = 4
+		v.ResetToDefault()
+		assert.Equal(t, []int{1, 2}, v.Load(), "ResetToDefault didn't restore the default.")
+	})
+}
+
+func TestValueStats(t *testing.T) {
+	v := NewValue(1, CollectStats[int]())
+	v.Store(2)
+	v.Swap(3)
+	v.CompareAndSwap(3, 4)
+	v.CompareAndSwap(3, 5)
+	v.TryStore(6)
+
+	assert.Equal(t, ValueStats{Stores: 3, Swaps: 1, CASAttempts: 2, CASFailures: 1}, v.Stats(),
+		"Stats didn't reflect the operations performed.")
+
+	t.Run("disabled", func(t *testing.T) {
+		v := NewValue(1)
+		v.Store(2)
+		v.CompareAndSwap(2, 3)
+		assert.Zero(t, v.Stats(), "Stats of a Value without CollectStats weren't zero.")
+	})
+}
+
+func BenchmarkValueStats(b *testing.B) {
+	writes := func(v *Value[int]) func(b *testing.B) {
+		return func(b *testing.B) {
+			b.Run("Store", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					v.Store(1)
+				}
+			})
+
+			b.Run("CompareAndSwap", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					v.CompareAndSwap(1, 1)
+				}
+			})
+		}
+	}
+
+	b.Run("disabled", writes(NewValue(1)))
+	b.Run("enabled", writes(NewValue(1, CollectStats[int]())))
+}
+
+func TestValueOnReplace(t *testing.T) {
+	var (
+		v       Value[string]
+		retired []string
+	)
+	v.OnReplace(func(old string) { retired = append(retired, old) })
+
+	v.Store("a")
+	assert.Empty(t, retired, "OnReplace function was called for the first Store.")
+
+	v.Store("b")
+	v.Swap("c")
+	v.CompareAndSwap("c", "d")
+	v.CompareAndSwap("c", "e")
+	v.TryStore("f")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, retired, "OnReplace function wasn't called with every retired value.")
+
+	v.OnReplace(nil)
+	v.Store("g")
+	assert.Len(t, retired, 4, "OnReplace function was called after removing it.")
+}
+
+func TestValueOnReplaceOrder(t *testing.T) {
+	writes := map[string]func(v *Value[int]){
+		"Store":             func(v *Value[int]) { v.Store(2) },
+		"TryStore":          func(v *Value[int]) { v.TryStore(2) },
+		"Swap":              func(v *Value[int]) { v.Swap(2) },
+		"CompareAndSwap":    func(v *Value[int]) { v.CompareAndSwap(1, 2) },
+		"CompareAndSwapErr": func(v *Value[int]) { _, _ = v.CompareAndSwapErr(1, 2) },
+		"ImportState":       func(v *Value[int]) { _ = v.ImportState(NewValue(2).ExportState()) },
+	}
+	for name, write := range writes {
+		write := write
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			v := NewValue(1, CollectStats[int]())
+			_, updates := v.LoadAndWatch(ctx)
+			var (
+				called   bool
+				stats    ValueStats
+				notified int
+			)
+			v.OnReplace(func(int) {
+				called, stats = true, v.Stats()
+				select {
+				case notified = <-updates:
+				default:
+				}
+			})
+			write(v)
+
+			require.True(t, called, "OnReplace function wasn't called.")
+			assert.Equal(t, uint64(2), stats.Stores+stats.Swaps+stats.CASAttempts, "write wasn't counted before calling the OnReplace function.")
+			assert.Equal(t, 2, notified, "watchers weren't notified before calling the OnReplace function.")
+		})
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	s := []*Value[int]{NewValue(1), NewValue(2)}
+	assert.Equal(t, 2, LoadAt(s, 1), "LoadAt didn't load the Value at the index.")
+
+	StoreAt(s, 0, 3)
+	assert.Equal(t, 3, s[0].Load(), "StoreAt didn't store to the Value at the index.")
+
+	assert.True(t, CompareAndSwapAt(s, 1, 2, 4), "CompareAndSwapAt didn't swap.")
+	assert.False(t, CompareAndSwapAt(s, 1, 2, 5), "CompareAndSwapAt swapped with a mismatching old value.")
+	assert.Equal(t, 4, s[1].Load(), "CompareAndSwapAt didn't set the correct value.")
+
+	for _, i := range []int{-1, 2} {
+		assert.PanicsWithValue(t, fmt.Sprintf("atomic: index %v out of range for 2 Values", i), func() { LoadAt(s, i) },
+			"LoadAt didn't panic for index %v.", i)
+		assert.Panics(t, func() { StoreAt(s, i, 0) }, "StoreAt didn't panic for index %v.", i)
+		assert.Panics(t, func() { CompareAndSwapAt(s, i, 0, 1) }, "CompareAndSwapAt didn't panic for index %v.", i)
+	}
+}
+
+func TestValueLoadOr(t *testing.T) {
+	var v Value[string]
+	assert.Equal(t, "fallback", v.LoadOr("fallback"), "LoadOr of an empty Value didn't return the fallback.")
+	assert.False(t, v.IsSet(), "LoadOr stored to the Value.")
+
+	v.Store("")
+	assert.Equal(t, "", v.LoadOr("fallback"), "LoadOr of a Value holding the zero value returned the fallback.")
+}
+
+func TestValueStoreAndCheck(t *testing.T) {
+	v := NewValue(1)
+	assert.False(t, v.StoreAndCheck(2, 3), "StoreAndCheck reported a value not equal to the target.")
+	assert.True(t, v.StoreAndCheck(3, 3), "StoreAndCheck didn't report a value equal to the target.")
+	assert.Equal(t, 3, v.Load(), "StoreAndCheck didn't store the value.")
+
+	s := NewValue([]int{1})
+	eq := func(a, b []int) bool { return len(a) == len(b) }
+	assert.Panics(t, func() { s.StoreAndCheck([]int{2}, []int{3}) }, "StoreAndCheck didn't panic for an uncomparable type.")
+	assert.False(t, s.StoreAndCheckFunc([]int{2, 3}, []int{4}, eq), "StoreAndCheckFunc reported a value not equal to the target.")
+	assert.True(t, s.StoreAndCheckFunc([]int{5}, []int{4}, eq), "StoreAndCheckFunc didn't report a value equal to the target.")
+	assert.Equal(t, []int{5}, s.Load(), "StoreAndCheckFunc didn't store the value.")
+}
+
+func TestLoadAll(t *testing.T) {
+	assert.Empty(t, LoadAll[int](), "LoadAll without Values didn't return an empty slice.")
+	assert.Equal(t, []int{1, 0, 3}, LoadAll(NewValue(1), &Value[int]{}, NewValue(3)),
+		"LoadAll didn't return the values in order.")
+}
+
+func TestValueStoreIfNewer(t *testing.T) {
+	type event struct {
+		name string
+		ts   int64
+	}
+	tsOf := func(e event) int64 { return e.ts }
+
+	var v Value[event]
+	assert.True(t, v.StoreIfNewer(event{"b", 2}, 2, tsOf), "StoreIfNewer didn't store to an unset Value.")
+	assert.False(t, v.StoreIfNewer(event{"a", 1}, 1, tsOf), "StoreIfNewer stored an older value.")
+	assert.False(t, v.StoreIfNewer(event{"c", 2}, 2, tsOf), "StoreIfNewer stored a value with an equal timestamp.")
+	assert.True(t, v.StoreIfNewer(event{"d", 4}, 4, tsOf), "StoreIfNewer didn't store a newer value.")
+	assert.False(t, v.StoreIfNewer(event{"e", 3}, 3, tsOf), "StoreIfNewer stored an older value.")
+	assert.Equal(t, event{"d", 4}, v.Load(), "StoreIfNewer didn't keep only the newest value.")
+
+	var (
+		concurrent Value[event]
+		wg         sync.WaitGroup
+	)
+	wg.Add(100)
+	for i := 0; i < 100; i++ {
+		i := int64(i)
+		go func() {
+			defer wg.Done()
+			concurrent.StoreIfNewer(event{ts: i}, i, tsOf)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(99), concurrent.Load().ts, "concurrent StoreIfNewer didn't keep the newest value.")
+}
+
+func TestValueLoadOrWait(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		v := NewValue(1)
+		assert.Equal(t, 1, v.LoadOrWait(context.Background(), 2), "LoadOrWait of a set Value didn't return its value.")
+	})
+
+	t.Run("waited", func(t *testing.T) {
+		var v Value[int]
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			v.Store(1)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.Equal(t, 1, v.LoadOrWait(ctx, 2), "LoadOrWait didn't return the value of the first Store.")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var v Value[int]
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, 2, v.LoadOrWait(ctx, 2), "LoadOrWait of an unset Value didn't return fallback on timeout.")
+		assert.False(t, v.IsSet(), "LoadOrWait stored to the Value.")
+	})
+}
+
+func TestValueReady(t *testing.T) {
+	isClosed := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var v Value[int]
+	ready := v.Ready()
+	assert.False(t, isClosed(ready), "Ready channel of an unset Value was closed.")
+	assert.True(t, ready == v.Ready(), "Ready didn't return the same channel.")
+
+	v.Store(0)
+	assert.True(t, isClosed(ready), "Store didn't close the Ready channel.")
+	v.Store(1)
+	assert.True(t, ready == v.Ready(), "Ready didn't return the same channel after a Store.")
+
+	stored := NewValue(1)
+	assert.True(t, isClosed(stored.Ready()), "Ready channel of a set Value wasn't closed.")
+
+	var swapped Value[int]
+	ready = swapped.Ready()
+	assert.False(t, swapped.CompareAndSwap(1, 2), "CompareAndSwap of an unset Value swapped.")
+	assert.False(t, isClosed(ready), "failed CompareAndSwap closed the Ready channel.")
+	swapped.Swap(1)
+	assert.True(t, isClosed(ready), "Swap didn't close the Ready channel.")
+
+	var concurrent Value[int]
+	go concurrent.Store(1)
+	select {
+	case <-concurrent.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("Ready channel wasn't closed by a concurrent Store.")
+	}
+}
+
+func TestValueLoadType(t *testing.T) {
+	var v Value[any]
+	assert.Nil(t, v.LoadType(), "LoadType of an unset Value didn't return nil.")
+	assert.False(t, Is[int](&v), "Is of an unset Value returned true.")
+
+	v.Store(1)
+	assert.Equal(t, reflect.TypeOf(0), v.LoadType(), "LoadType didn't return the type held.")
+	assert.True(t, Is[int](&v), "Is didn't report the type held.")
+	assert.False(t, Is[string](&v), "Is reported a type not held.")
+
+	v.Store(fmt.Errorf("foo"))
+	assert.True(t, Is[error](&v), "Is didn't report an implemented interface.")
+
+	v.Store(nil)
+	assert.Nil(t, v.LoadType(), "LoadType of a nil interface value didn't return nil.")
+}
+
+func TestStoreIfNewPointer(t *testing.T) {
+	type config struct{ name string }
+
+	var (
+		first  = &config{"foo"}
+		second = &config{"foo"}
+		v      = NewValue(first, CollectStats[*config]())
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, updates := v.LoadAndWatch(ctx)
+
+	assert.False(t, StoreIfNewPointer(v, first), "StoreIfNewPointer of the pointer held stored it.")
+	select {
+	case <-updates:
+		t.Fatal("StoreIfNewPointer of the pointer held notified watchers.")
+	default:
+	}
+	assert.Zero(t, v.Stats().CASAttempts, "StoreIfNewPointer of the pointer held wrote to the Value.")
+
+	assert.True(t, StoreIfNewPointer(v, second), "StoreIfNewPointer of an equal but distinct pointer didn't store it.")
+	assert.True(t, v.Load() == second, "StoreIfNewPointer didn't store the new pointer.")
+	assert.True(t, <-updates == second, "StoreIfNewPointer of a new pointer didn't notify watchers.")
+
+	var empty Value[*config]
+	assert.False(t, StoreIfNewPointer(&empty, nil), "StoreIfNewPointer of nil into an empty Value stored it.")
+	assert.True(t, StoreIfNewPointer(&empty, first), "StoreIfNewPointer into an empty Value didn't store.")
+}
+
+func TestTransfer(t *testing.T) {
+	var from, to Value[string]
+	assert.False(t, Transfer(&from, &to), "Transfer from an unset Value reported a move.")
+	assert.False(t, from.IsSet() || to.IsSet(), "Transfer from an unset Value stored to a Value.")
+
+	from.Store("foo")
+	to.Store("bar")
+	assert.True(t, Transfer(&from, &to), "Transfer didn't report a move.")
+	assert.Equal(t, "", from.Load(), "Transfer didn't clear from.")
+	assert.Equal(t, "foo", to.Load(), "Transfer didn't store the value into to.")
+}
+
+func TestUpdateEmit(t *testing.T) {
+	v := NewValue(0)
+	out := UpdateEmit(v, func(old int) (int, string) {
+		if old == 0 {
+			return 1, "start"
+		}
+		return old + 1, "continue"
+	})
+	assert.Equal(t, "start", out, "UpdateEmit didn't return the out value of the committed run.")
+	assert.Equal(t, 1, v.Load(), "UpdateEmit didn't store the next value.")
+
+	var (
+		wg     sync.WaitGroup
+		emits  = make([]int, 8)
+		counts = make(map[int]int)
+	)
+	wg.Add(len(emits))
+	for i := range emits {
+		i := i
+		go func() {
+			defer wg.Done()
+			emits[i] = UpdateEmit(v, func(old int) (int, int) { return old + 1, old })
+		}()
+	}
+	wg.Wait()
+	for _, e := range emits {
+		counts[e]++
+	}
+	assert.Len(t, counts, len(emits), "concurrent UpdateEmit calls returned out values of the same transition.")
+	assert.Equal(t, 1+len(emits), v.Load(), "concurrent UpdateEmit lost updates.")
+}
+
+func TestValueRecordLastWriter(t *testing.T) {
+	location := func() string {
+		_, file, line, _ := runtime.Caller(1)
+		return fmt.Sprintf("%v:%v", file, line-1)
+	}
+
+	assert.Empty(t, NewValue(1).LastWriter(), "LastWriter of a Value without RecordLastWriter wasn't empty.")
+
+	v := NewValue(1, RecordLastWriter[int]())
+	want := location()
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of NewValue.")
+
+	v.Store(2)
+	want = location()
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of Store.")
+
+	v.Swap(3)
+	want = location()
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of Swap.")
+
+	v.CompareAndSwap(3, 4)
+	want = location()
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of CompareAndSwap.")
+
+	v.CompareAndSwap(3, 5)
+	assert.Equal(t, want, v.LastWriter(), "failed CompareAndSwap changed the last writer.")
+
+	_, err := fmt.Sscan("6", v.Scanner())
+	want = location()
+	require.NoError(t, err, "Sscan into the Scanner of a Value failed.")
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of Sscan.")
+
+	UpdateEmit(v, func(old int) (int, bool) { return old + 1, true })
+	want = location()
+	assert.Equal(t, want, v.LastWriter(), "LastWriter didn't return the location of UpdateEmit.")
+
+	n := NewValue(sql.NullInt64{}, RecordLastWriter[sql.NullInt64]())
+	require.NoError(t, n.Scan(int64(1)), "Scan of a Value failed.")
+	want = location()
+	assert.Equal(t, want, n.LastWriter(), "LastWriter didn't return the location of Scan.")
+}
+
+func TestValueUpdateIfChanged(t *testing.T) {
+	v := NewValue(1, CollectStats[int]())
+	new, changed := v.UpdateIfChanged(func(old int) int { return old })
+	assert.False(t, changed, "UpdateIfChanged reported a write for an unchanged value.")
+	assert.Equal(t, 1, new, "UpdateIfChanged didn't return the value held.")
+	assert.Zero(t, v.Stats().CASAttempts, "UpdateIfChanged wrote an unchanged value.")
+
+	new, changed = v.UpdateIfChanged(func(old int) int { return old + 1 })
+	assert.True(t, changed, "UpdateIfChanged didn't report a write for a changed value.")
+	assert.Equal(t, 2, new, "UpdateIfChanged didn't return the new value.")
+	assert.Equal(t, 2, v.Load(), "UpdateIfChanged didn't store the new value.")
+
+	type config struct{ name string }
+	p := NewValue(&config{"foo"}, CollectStats[*config]())
+	eq := func(a, b *config) bool { return *a == *b }
+
+	_, changed = p.UpdateIfChangedFunc(func(*config) *config { return &config{"foo"} }, eq)
+	assert.False(t, changed, "UpdateIfChangedFunc reported a write for an equal value.")
+	assert.Zero(t, p.Stats().CASAttempts, "UpdateIfChangedFunc wrote an equal value.")
+
+	_, changed = p.UpdateIfChangedFunc(func(*config) *config { return &config{"bar"} }, eq)
+	assert.True(t, changed, "UpdateIfChangedFunc didn't report a write for a different value.")
+	assert.Equal(t, "bar", p.Load().name, "UpdateIfChangedFunc didn't store the new value.")
+}
+
+// scannableLevel is a type implementing fmt.Scanner, which parses a level name into its number.
+type scannableLevel int
+
+func (l *scannableLevel) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	switch string(tok) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", tok)
+	}
+	return nil
+}
+
+func TestValueScanner(t *testing.T) {
+	var (
+		n    Value[int]
+		name Value[string]
+	)
+	_, err := fmt.Sscan("42 foo", n.Scanner(), name.Scanner())
+	require.NoError(t, err, "Sscan into basic types failed.")
+	assert.Equal(t, 42, n.Load(), "Sscan didn't store the int scanned.")
+	assert.Equal(t, "foo", name.Load(), "Sscan didn't store the string scanned.")
+
+	_, err = fmt.Sscan("bar", n.Scanner())
+	assert.Error(t, err, "Sscan of an invalid int didn't fail.")
+	assert.Equal(t, 42, n.Load(), "failed Sscan modified the Value.")
+
+	var level Value[scannableLevel]
+	_, err = fmt.Sscan("high", level.Scanner())
+	require.NoError(t, err, "Sscan into a fmt.Scanner failed.")
+	assert.Equal(t, scannableLevel(2), level.Load(), "Sscan didn't use the Scan method of T.")
+
+	var unsupported Value[[]int]
+	_, err = fmt.Sscan("1", unsupported.Scanner())
+	assert.EqualError(t, err, "atomic: cannot scan into Value[[]int]: []int is not a basic type and *[]int does not implement fmt.Scanner",
+		"Sscan into an unsupported type didn't fail.")
+}
+
+func TestValueFreeze(t *testing.T) {
+	v := NewValue(1)
+	assert.False(t, v.IsFrozen(), "new Value was frozen.")
+	require.NoError(t, v.StoreErr(2), "StoreErr of a Value that isn't frozen failed.")
+
+	v.Freeze()
+	assert.True(t, v.IsFrozen(), "Freeze didn't freeze the Value.")
+	assert.PanicsWithValue(t, ErrFrozen, func() { v.Store(3) }, "Store of a frozen Value didn't panic.")
+	assert.PanicsWithValue(t, ErrFrozen, func() { v.Swap(3) }, "Swap of a frozen Value didn't panic.")
+	assert.PanicsWithValue(t, ErrFrozen, func() { v.CompareAndSwap(2, 3) }, "CompareAndSwap of a frozen Value didn't panic.")
+	assert.PanicsWithValue(t, ErrFrozen, func() { v.UpdateIfChanged(func(old int) int { return old + 1 }) },
+		"UpdateIfChanged of a frozen Value didn't panic.")
+
+	assert.Equal(t, ErrFrozen, v.StoreErr(3), "StoreErr of a frozen Value didn't return ErrFrozen.")
+	swapped, err := v.CompareAndSwapErr(2, 3)
+	assert.False(t, swapped, "CompareAndSwapErr of a frozen Value swapped.")
+	assert.Equal(t, ErrFrozen, err, "CompareAndSwapErr of a frozen Value didn't return ErrFrozen.")
+
+	assert.Equal(t, 2, v.Load(), "Load of a frozen Value didn't return the value held.")
+}
+
+func TestValueUpdateAll(t *testing.T) {
+	var (
+		v       = NewValue(1, CollectStats[int]())
+		retired []int
+	)
+	v.OnReplace(func(old int) { retired = append(retired, old) })
+
+	double := func(x int) int { return x * 2 }
+	inc := func(x int) int { return x + 1 }
+	assert.Equal(t, 5, v.UpdateAll(double, inc, double, func(x int) int { return x - 1 }),
+		"UpdateAll didn't apply the functions in order.")
+	assert.Equal(t, 5, v.Load(), "UpdateAll didn't store the final result.")
+	assert.Equal(t, uint64(1), v.Stats().CASAttempts, "UpdateAll didn't write exactly once.")
+	assert.Equal(t, []int{1}, retired, "UpdateAll published intermediate results.")
+
+	assert.Equal(t, 5, v.UpdateAll(), "UpdateAll without functions modified the value.")
+}
+
+func TestNewFromChannel(t *testing.T) {
+	waitExit := func(done <-chan struct{}, msg string) {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.FailNow(t, msg)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	v, done := newFromChannel(ctx, ch)
+	assert.False(t, v.IsSet(), "NewFromChannel returned a set Value.")
+
+	ch <- 1
+	ch <- 2
+	cancel()
+	waitExit(done, "goroutine didn't exit on cancellation.")
+	assert.Equal(t, 2, v.Load(), "Value didn't keep the last value received.")
+
+	closed := make(chan int, 1)
+	v, done = newFromChannel(context.Background(), closed)
+	closed <- 3
+	close(closed)
+	waitExit(done, "goroutine didn't exit after the channel closed.")
+	assert.Equal(t, 3, v.Load(), "Value didn't hold the last value received before closing.")
+}
+
+func TestValueCompareAndSwapRetry(t *testing.T) {
+	v := NewValue(1)
+	swapped, attempts := v.CompareAndSwapRetry(1, 2, 3)
+	assert.True(t, swapped, "CompareAndSwapRetry of a matching value didn't swap.")
+	assert.Equal(t, 1, attempts, "CompareAndSwapRetry of a matching value didn't swap on the first attempt.")
+
+	swapped, attempts = v.CompareAndSwapRetry(1, 3, 4)
+	assert.False(t, swapped, "CompareAndSwapRetry of a mismatching value swapped.")
+	assert.Equal(t, 4, attempts, "CompareAndSwapRetry didn't exhaust maxAttempts.")
+
+	swapped, attempts = v.CompareAndSwapRetry(1, 3, 0)
+	assert.False(t, swapped, "CompareAndSwapRetry of a mismatching value swapped.")
+	assert.Equal(t, 1, attempts, "CompareAndSwapRetry with maxAttempts 0 didn't make exactly one attempt.")
+
+	// The value only starts matching after the first attempt failed, and CompareAndSwapRetry keeps retrying until it
+	// does.
+	v = NewValue(3, CollectStats[int]())
+	go func() {
+		for v.Stats().CASFailures == 0 {
+			runtime.Gosched()
+		}
+		v.Store(1)
+	}()
+	swapped, attempts = v.CompareAndSwapRetry(1, 4, math.MaxInt)
+	assert.True(t, swapped, "CompareAndSwapRetry didn't swap once the value matched.")
+	assert.True(t, attempts > 1, "CompareAndSwapRetry swapped before the value matched.")
+	assert.Equal(t, 4, v.Load(), "CompareAndSwapRetry didn't store the new value.")
+
+	start := time.Now()
+	swapped, attempts = v.CompareAndSwapRetry(1, 5, 100)
+	assert.False(t, swapped, "CompareAndSwapRetry of a mismatching value swapped.")
+	assert.Equal(t, 100, attempts, "CompareAndSwapRetry didn't exhaust maxAttempts.")
+	assert.True(t, time.Since(start) < 5*time.Second, "CompareAndSwapRetry didn't cap its backoff.")
+}
+
+func TestValueCompareAndSwapOrCurrent(t *testing.T) {
+	v := NewValue("idle")
+	current, swapped := v.CompareAndSwapOrCurrent("idle", "running")
+	assert.True(t, swapped, "CompareAndSwapOrCurrent of a matching value didn't swap.")
+	assert.Equal(t, "idle", current, "CompareAndSwapOrCurrent didn't return the old value after swapping.")
+
+	current, swapped = v.CompareAndSwapOrCurrent("idle", "stopped")
+	assert.False(t, swapped, "CompareAndSwapOrCurrent of a mismatching value swapped.")
+	assert.Equal(t, "running", current, "CompareAndSwapOrCurrent didn't return the blocking value.")
+	assert.Equal(t, "running", v.Load(), "CompareAndSwapOrCurrent of a mismatching value modified the value.")
+
+	var empty Value[string]
+	current, swapped = empty.CompareAndSwapOrCurrent("idle", "running")
+	assert.False(t, swapped, "CompareAndSwapOrCurrent of an empty value swapped.")
+	assert.Equal(t, "", current, "CompareAndSwapOrCurrent of an empty value didn't return the zero value.")
+
+	current, swapped = empty.CompareAndSwapOrCurrent("", "running")
+	assert.False(t, swapped, "CompareAndSwapOrCurrent of an empty value from the zero value swapped.")
+	assert.Equal(t, "", current, "CompareAndSwapOrCurrent of an empty value didn't return the zero value.")
+	assert.False(t, empty.IsSet(), "CompareAndSwapOrCurrent of an empty value set the Value.")
+}
+
+func TestValueOnCASFailure(t *testing.T) {
+	var (
+		v        = NewValue(0)
+		attempts []int
+	)
+	v.OnCASFailure(func(attempt int) { attempts = append(attempts, attempt) })
+
+	interfere := 2
+	v.UpdateAll(func(old int) int {
+		// Induce contention by writing to the Value before the update is published.
+		if interfere > 0 {
+			interfere--
+			v.Store(old + 10)
+		}
+		return old + 1
+	})
+	assert.Equal(t, []int{1, 2}, attempts, "OnCASFailure function wasn't called for every failed attempt.")
+	assert.Equal(t, 21, v.Load(), "update didn't succeed after failed attempts.")
+
+	v.CompareAndSwap(0, 1)
+	assert.Len(t, attempts, 2, "OnCASFailure function was called for a failed CompareAndSwap.")
+
+	v.OnCASFailure(nil)
+	interfere = 1
+	v.UpdateAll(func(old int) int {
+		if interfere > 0 {
+			interfere--
+			v.Store(old + 10)
+		}
+		return old + 1
+	})
+	assert.Len(t, attempts, 2, "OnCASFailure function was called after removing it.")
+}
+
+func TestValueSwapForCleanup(t *testing.T) {
+	type resource struct{ closed Int32 }
+
+	var v Value[*resource]
+	old, wasSet := v.SwapForCleanup(&resource{})
+	assert.False(t, wasSet, "SwapForCleanup of an unset Value reported a previous value.")
+	assert.Nil(t, old, "SwapForCleanup of an unset Value didn't return the zero value.")
+
+	var (
+		wg        sync.WaitGroup
+		resources = []*resource{v.Load()}
+		mu        sync.Mutex
+	)
+	wg.Add(8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer wg.Done()
+			r := &resource{}
+			mu.Lock()
+			resources = append(resources, r)
+			mu.Unlock()
+			if old, wasSet := v.SwapForCleanup(r); wasSet {
+				old.closed.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	v.Load().closed.Inc()
+
+	for i, r := range resources {
+		assert.Equal(t, int32(1), r.closed.Load(), "resource %v wasn't returned exactly once.", i)
+	}
+}
+
+func TestValueStoreWithHooks(t *testing.T) {
+	type handler func() string
+
+	var (
+		v      Value[handler]
+		events []string
+	)
+	onOld := func(h handler) { events = append(events, "old "+h()) }
+	onNew := func(h handler) { events = append(events, "new "+h()) }
+
+	v.StoreWithHooks(func() string { return "a" }, onOld, onNew)
+	assert.Equal(t, []string{"new a"}, events, "StoreWithHooks of an unset Value called onOld.")
+
+	v.StoreWithHooks(func() string { return "b" }, onOld, onNew)
+	assert.Equal(t, []string{"new a", "old a", "new b"}, events, "StoreWithHooks didn't call the hooks in order.")
+	assert.Equal(t, "b", v.Load()(), "StoreWithHooks didn't store the new value.")
+
+	v.StoreWithHooks(func() string { return "c" }, nil, nil)
+	assert.Equal(t, "c", v.Load()(), "StoreWithHooks with nil hooks didn't store the new value.")
+}
+
+func TestValueExportImportState(t *testing.T) {
+	src := NewValue("foo")
+	dst := NewValue("bar")
+	var replaced []string
+	dst.OnReplace(func(old string) { replaced = append(replaced, old) })
+
+	require.NoError(t, dst.ImportState(src.ExportState()), "ImportState of an exported state failed.")
+	assert.Equal(t, "foo", dst.Load(), "ImportState didn't import the value exported.")
+	assert.Equal(t, []string{"bar"}, replaced, "ImportState didn't call the OnReplace hook.")
+
+	var empty, other Value[string]
+	assert.Nil(t, empty.ExportState(), "ExportState of an empty Value wasn't nil.")
+	assert.NoError(t, other.ImportState(empty.ExportState()), "ImportState of an empty state into an empty Value failed.")
+	assert.False(t, other.IsSet(), "ImportState of an empty state set the Value.")
+	assert.Error(t, dst.ImportState(nil), "ImportState of an empty state into a set Value succeeded.")
+	assert.Equal(t, "foo", dst.Load(), "failed ImportState modified the Value.")
+
+	assert.Error(t, dst.ImportState(NewValue(1).ExportState()), "ImportState of a state of another type succeeded.")
+	assert.Error(t, dst.ImportState("foo"), "ImportState of a foreign state succeeded.")
+
+	dst.Freeze()
+	assert.Equal(t, ErrFrozen, dst.ImportState(src.ExportState()), "ImportState into a frozen Value didn't return ErrFrozen.")
+}
+
+func TestValueWith(t *testing.T) {
+	type pair struct{ a, b int }
+
+	var (
+		v    Value[pair]
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				v.Store(pair{a: i, b: i})
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		v.With(func(p pair) {
+			assert.Equal(t, p.a, p.b, "With observed a torn value.")
+		})
+	}
+	close(done)
+	wg.Wait()
+
+	var empty Value[pair]
+	empty.With(func(p pair) {
+		assert.Equal(t, pair{}, p, "With of an empty Value didn't pass the zero value.")
+	})
+}
+
+// binaryPoint is a type implementing AppendBinary.
+type binaryPoint struct{ x, y byte }
+
+func (p binaryPoint) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, p.x, p.y), nil
+}
+
+// binaryMarshalerPoint is a type implementing encoding.BinaryMarshaler, but not AppendBinary.
+type binaryMarshalerPoint struct{ x, y byte }
+
+func (p binaryMarshalerPoint) MarshalBinary() ([]byte, error) {
+	return []byte{p.x, p.y}, nil
+}
+
+func TestValueAppendBinary(t *testing.T) {
+	v := NewValue(binaryPoint{1, 2})
+	buf := make([]byte, 1, 8)
+	b, err := v.AppendBinary(buf)
+	require.NoError(t, err, "AppendBinary failed for a type implementing AppendBinary.")
+	assert.Equal(t, []byte{0, 1, 2}, b, "AppendBinary didn't append the encoding.")
+	assert.True(t, &b[0] == &buf[0], "AppendBinary didn't reuse the buffer passed.")
+
+	b, err = NewValue(binaryMarshalerPoint{3, 4}).AppendBinary([]byte{9})
+	require.NoError(t, err, "AppendBinary failed for a type implementing encoding.BinaryMarshaler.")
+	assert.Equal(t, []byte{9, 3, 4}, b, "AppendBinary didn't append the result of MarshalBinary.")
+
+	_, err = NewValue(1).AppendBinary(nil)
+	assert.EqualError(t, err, "atomic: cannot append binary encoding of Value[int]: int implements neither AppendBinary nor encoding.BinaryMarshaler",
+		"AppendBinary of an unsupported type didn't fail.")
+}
+
+func TestValueInitFrom(t *testing.T) {
+	var (
+		called []string
+		empty  = func(name string) func() (string, bool) {
+			return func() (string, bool) {
+				called = append(called, name)
+				return "", false
+			}
+		}
+		source = func(name string) func() (string, bool) {
+			return func() (string, bool) {
+				called = append(called, name)
+				return name, true
+			}
+		}
+	)
+
+	var v Value[string]
+	val, ok := v.InitFrom(empty("env"), empty("file"))
+	assert.False(t, ok, "InitFrom with only empty sources reported a value.")
+	assert.Equal(t, "", val, "InitFrom with only empty sources didn't return the zero value.")
+	assert.False(t, v.IsSet(), "InitFrom with only empty sources stored to the Value.")
+
+	called = nil
+	val, ok = v.InitFrom(empty("env"), source("file"), source("default"))
+	assert.True(t, ok, "InitFrom didn't report the value of a source.")
+	assert.Equal(t, "file", val, "InitFrom didn't return the first available value.")
+	assert.Equal(t, "file", v.Load(), "InitFrom didn't store the first available value.")
+	assert.Equal(t, []string{"env", "file"}, called, "InitFrom didn't stop at the first available value.")
+
+	called = nil
+	val, ok = v.InitFrom(source("other"))
+	assert.True(t, ok && val == "file", "InitFrom of a set Value didn't return the value held.")
+	assert.Empty(t, called, "InitFrom of a set Value called a source.")
+}
+
+func TestValueFingerprint(t *testing.T) {
+	hash := func(s []string) uint64 {
+		h := fnv.New64a()
+		for _, elem := range s {
+			_, _ = h.Write([]byte(elem))
+			_, _ = h.Write([]byte{0})
+		}
+		return h.Sum64()
+	}
+
+	v := NewValue([]string{"a", "b"})
+	fingerprint := v.Fingerprint(hash)
+	assert.Equal(t, fingerprint, NewValue([]string{"a", "b"}).Fingerprint(hash),
+		"equal content didn't yield equal fingerprints.")
+
+	v.Store([]string{"a", "b"})
+	_, changed := v.FingerprintChanged(fingerprint, hash)
+	assert.False(t, changed, "FingerprintChanged reported a change for equal content.")
+
+	v.Store([]string{"ab"})
+	next, changed := v.FingerprintChanged(fingerprint, hash)
+	assert.True(t, changed, "FingerprintChanged didn't report a change of content.")
+	assert.Equal(t, v.Fingerprint(hash), next, "FingerprintChanged didn't return the new fingerprint.")
 }
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// valueWatchers holds the channels of the watchers of a Value registered using LoadAndWatch. chans is allocated
+// when the first watcher is registered.
+type valueWatchers[T any] struct {
+	n Int32
+
+	mu    sync.Mutex
+	chans map[chan T]struct{}
+}
+
+// LoadAndWatch returns the value currently held together with a channel on which the values of subsequent writes
+// to the Value are delivered, until ctx is done, at which point the channel is closed. The value is loaded after the
+// watcher is registered, under the same lock that writes notify watchers with, so that no write between the load
+// and the registration is missed.
+//
+// Writes never block on watchers. Each watcher buffers a single value: if a watcher falls behind, a pending value is
+// replaced by a newer one, so that intermediate values may be skipped, but the value held after the last write is
+// always delivered. A watcher may also receive the same value more than once, including the current value returned.
+func (v *Value[T]) LoadAndWatch(ctx context.Context) (current T, updates <-chan T) {
+	w := &v.loadOrCreateExtension().watchers
+
+	ch := make(chan T, 1)
+	w.mu.Lock()
+	if w.chans == nil {
+		w.chans = map[chan T]struct{}{}
+	}
+	w.chans[ch] = struct{}{}
+	w.n.Inc()
+	current = v.Load()
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.chans, ch)
+		w.n.Dec()
+		close(ch)
+	}()
+	return current, ch
+}
+
+// WaitSettled blocks until the Value was not written to for a quiet period of d, and returns the value held at that
+// point. This is useful for waiting until a value that is updated in bursts, such as configuration, stabilises. The
+// quiet period starts when WaitSettled is called and restarts on every write to the Value. If ctx is done before the
+// Value settles, WaitSettled returns the zero value of T and ctx.Err().
+func (v *Value[T]) WaitSettled(ctx context.Context, d time.Duration) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, updates := v.LoadAndWatch(ctx)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-updates:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(d)
+		case <-timer.C:
+			return v.Load(), nil
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// notifyWatchers delivers the value currently held to all watchers in w, which must be the watchers of the Value
+// registered using LoadAndWatch. notifyWatchers must be called after writing to the Value, so that a watcher
+// registered concurrently either loads the new value in LoadAndWatch or is notified of it.
+func (v *Value[T]) notifyWatchers(w *valueWatchers[T]) {
+	if w.n.Load() == 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	val := v.Load()
+	for ch := range w.chans {
+		// Replace a value the watcher has not yet received. Only notifyWatchers sends on ch, with w.mu held, so the
+		// send below never blocks.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- val
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueLoadAndWatch(t *testing.T) {
+	v := NewValue(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	current, updates := v.LoadAndWatch(ctx)
+	assert.Equal(t, 1, current, "LoadAndWatch didn't return the current value.")
+
+	v.Store(2)
+	select {
+	case val := <-updates:
+		assert.Equal(t, 2, val, "watcher didn't receive the value stored.")
+	case <-time.After(time.Second):
+		t.Fatal("watcher didn't receive the value stored.")
+	}
+
+	v.Store(3)
+	v.Swap(4)
+	assert.Equal(t, 4, <-updates, "watcher falling behind didn't receive the latest value.")
+
+	cancel()
+	for range updates {
+	}
+	v.Store(5)
+}
+
+func TestValueLoadAndWatchConcurrentStores(t *testing.T) {
+	const (
+		watchers = 8
+		stores   = 1000
+	)
+
+	var (
+		v  = NewValue(0)
+		wg sync.WaitGroup
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wg.Add(watchers)
+	go func() {
+		for i := 1; i <= stores; i++ {
+			v.Store(i)
+		}
+	}()
+	for i := 0; i < watchers; i++ {
+		go func() {
+			defer wg.Done()
+			last, updates := v.LoadAndWatch(ctx)
+			for last != stores {
+				select {
+				case val := <-updates:
+					if !assert.True(t, val >= last, "watcher received %v after %v.", val, last) {
+						return
+					}
+					last = val
+				case <-time.After(time.Second):
+					assert.Fail(t, "watcher missed the last value stored.", "last received: %v", last)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestValueWaitSettled(t *testing.T) {
+	// The quiet period is far longer than the synchronous burst of stores below, so that the burst always ends before
+	// the Value settles.
+	const quiet = 200 * time.Millisecond
+
+	var (
+		v    = NewValue(0)
+		val  int
+		err  error
+		done = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		val, err = v.WaitSettled(context.Background(), quiet)
+	}()
+	// Wait until WaitSettled watches the Value, so that every store below restarts its quiet period.
+	for e := v.extension(); e == nil || e.watchers.n.Load() == 0; e = v.extension() {
+		runtime.Gosched()
+	}
+	var last time.Time
+	for i := 1; i <= 20; i++ {
+		last = time.Now()
+		v.Store(i)
+	}
+	<-done
+	require.NoError(t, err, "WaitSettled of a settling Value failed.")
+	assert.Equal(t, 20, val, "WaitSettled returned before the burst of stores ended.")
+	assert.True(t, time.Since(last) >= quiet, "WaitSettled didn't restart the quiet period on stores.")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	val, err = v.WaitSettled(ctx, time.Hour)
+	assert.Equal(t, context.DeadlineExceeded, err, "WaitSettled didn't return the context error.")
+	assert.Equal(t, 0, val, "WaitSettled of a done context didn't return the zero value.")
+}
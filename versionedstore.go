@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// VersionedStore holds immutable versions of a value of type T, keyed by a version number, of which at most one is
+// active at a time. Activating a version atomically switches all readers over to it, and reactivating an older
+// version rolls back to it instantly, which suits blue/green activation of configuration. Active is lock-free. The
+// zero value is an empty VersionedStore ready to use.
+type VersionedStore[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	versions sync.Map
+	active   Value[*versionedEntry[T]]
+}
+
+// versionedEntry holds a version registered in a VersionedStore.
+type versionedEntry[T any] struct {
+	val     T
+	version uint64
+}
+
+// Register registers val as version and reports whether it was registered. Versions are immutable: if version was
+// already registered, Register has no effect and returns false.
+func (s *VersionedStore[T]) Register(version uint64, val T) (registered bool) {
+	_, loaded := s.versions.LoadOrStore(version, &versionedEntry[T]{val: val, version: version})
+	return !loaded
+}
+
+// Activate atomically makes version the active version and reports whether it was activated. Activate fails and
+// returns false if version was not registered.
+func (s *VersionedStore[T]) Activate(version uint64) (activated bool) {
+	e, ok := s.versions.Load(version)
+	if !ok {
+		return false
+	}
+	s.active.Store(e.(*versionedEntry[T]))
+	return true
+}
+
+// Active returns the value of the active version along with its version number. If no version was activated yet,
+// Active returns the zero value of T and version 0, which may be distinguished from an activated version 0 using
+// HasActive.
+func (s *VersionedStore[T]) Active() (val T, version uint64) {
+	if e := s.active.Load(); e != nil {
+		return e.val, e.version
+	}
+	return val, 0
+}
+
+// HasActive reports whether any version was activated.
+func (s *VersionedStore[T]) HasActive() bool {
+	return s.active.Load() != nil
+}
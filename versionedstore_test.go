@@ -0,0 +1,57 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionedStore(t *testing.T) {
+	var s VersionedStore[string]
+	assert.False(t, s.HasActive(), "empty VersionedStore has an active version.")
+	assert.False(t, s.Activate(1), "Activate of an unregistered version succeeded.")
+
+	assert.True(t, s.Register(1, "blue"), "Register of a new version failed.")
+	assert.True(t, s.Register(2, "green"), "Register of a new version failed.")
+	assert.False(t, s.Register(1, "red"), "Register of an existing version succeeded.")
+
+	assert.True(t, s.Activate(1), "Activate of a registered version failed.")
+	val, version := s.Active()
+	assert.Equal(t, "blue", val, "Register modified an existing version.")
+	assert.Equal(t, uint64(1), version, "Active didn't return the activated version.")
+
+	assert.True(t, s.Activate(2), "Activate of a registered version failed.")
+	val, version = s.Active()
+	assert.Equal(t, "green", val, "Activate didn't switch the active version.")
+	assert.Equal(t, uint64(2), version, "Activate didn't switch the active version.")
+
+	assert.False(t, s.Activate(3), "Activate of an unregistered version succeeded.")
+	_, version = s.Active()
+	assert.Equal(t, uint64(2), version, "failed Activate changed the active version.")
+
+	assert.True(t, s.Activate(1), "rollback to an older version failed.")
+	val, version = s.Active()
+	assert.Equal(t, "blue", val, "rollback didn't restore the older version.")
+	assert.Equal(t, uint64(1), version, "rollback didn't restore the older version.")
+	assert.True(t, s.HasActive(), "VersionedStore with an activated version has no active version.")
+}
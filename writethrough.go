@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import "sync"
+
+// WriteThrough is a value of type T that mirrors every write to a sink, such as a file or database, for example to
+// recover the value after a crash. In write-through mode, created using NewWriteThrough, the value is only updated
+// after the sink accepted it. In write-behind mode, created using NewWriteBehind, the value is updated immediately
+// and written to the sink asynchronously. Loads never involve the sink. WriteThroughs must be created using
+// NewWriteThrough or NewWriteBehind.
+type WriteThrough[T any] struct {
+	_ nocmp // disallow non-atomic comparison
+
+	v    Value[T]
+	sink func(T) error
+
+	// behind is true for write-behind mode, in which case errors returned by sink are passed to onError.
+	behind  bool
+	onError func(error)
+	pending Bool
+
+	// mu serialises calls to sink, so that the sink observes values in the order they were stored.
+	mu sync.Mutex
+}
+
+// NewWriteThrough creates a new, unset WriteThrough in write-through mode, in which Store passes every value to sink
+// before storing it, and only stores it if sink returns no error.
+func NewWriteThrough[T any](sink func(T) error) *WriteThrough[T] {
+	return &WriteThrough[T]{sink: sink}
+}
+
+// NewWriteBehind creates a new, unset WriteThrough in write-behind mode, in which Store stores every value
+// immediately and passes it to sink from another goroutine afterwards. Values stored in quick succession may be
+// coalesced, so that only the latest of them is passed to sink, but the latest value stored is always written
+// eventually. If sink returns an error, it is passed to onError, if not nil, and the value is still held.
+func NewWriteBehind[T any](sink func(T) error, onError func(error)) *WriteThrough[T] {
+	return &WriteThrough[T]{sink: sink, behind: true, onError: onError}
+}
+
+// Load atomically loads the value held.
+func (w *WriteThrough[T]) Load() T {
+	return w.v.Load()
+}
+
+// Store stores val and writes it to the sink. In write-through mode, Store returns the error returned by the sink,
+// in which case val is not stored. In write-behind mode, Store always stores val and returns nil, as the sink is
+// called asynchronously.
+func (w *WriteThrough[T]) Store(val T) error {
+	if w.behind {
+		w.v.Store(val)
+		if w.pending.CAS(false, true) {
+			go w.flush()
+		}
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.sink(val); err != nil {
+		return err
+	}
+	w.v.Store(val)
+	return nil
+}
+
+// flush writes the value currently held to the sink in write-behind mode.
+func (w *WriteThrough[T]) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Clear pending before loading the value, so that a Store after the load starts another flush.
+	w.pending.Store(false)
+	if err := w.sink(w.v.Load()); err != nil && w.onError != nil {
+		w.onError(err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package atomic
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteThrough(t *testing.T) {
+	var (
+		errSink = errors.New("sink failed")
+		written []int
+	)
+	w := NewWriteThrough(func(val int) error {
+		if val < 0 {
+			return errSink
+		}
+		written = append(written, val)
+		return nil
+	})
+
+	require.NoError(t, w.Store(1), "Store failed for a value accepted by the sink.")
+	assert.Equal(t, 1, w.Load(), "Store didn't store a value accepted by the sink.")
+
+	assert.Equal(t, errSink, w.Store(-1), "Store didn't return the error of the sink.")
+	assert.Equal(t, 1, w.Load(), "Store stored a value rejected by the sink.")
+
+	require.NoError(t, w.Store(2), "Store failed for a value accepted by the sink.")
+	assert.Equal(t, []int{1, 2}, written, "sink didn't receive the values stored in order.")
+}
+
+func TestWriteBehind(t *testing.T) {
+	var (
+		errSink = errors.New("sink failed")
+		written = make(chan int, 10)
+		errs    = make(chan error, 10)
+	)
+	w := NewWriteBehind(func(val int) error {
+		if val < 0 {
+			return errSink
+		}
+		written <- val
+		return nil
+	}, func(err error) { errs <- err })
+
+	require.NoError(t, w.Store(-1), "Store in write-behind mode returned an error.")
+	assert.Equal(t, -1, w.Load(), "Store in write-behind mode didn't store the value immediately.")
+	select {
+	case err := <-errs:
+		assert.Equal(t, errSink, err, "onError didn't receive the error of the sink.")
+	case <-time.After(time.Second):
+		t.Fatal("onError wasn't called for a failing sink.")
+	}
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, w.Store(i), "Store in write-behind mode returned an error.")
+	}
+	for {
+		select {
+		case val := <-written:
+			if val == 5 {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("sink didn't receive the latest value stored.")
+		}
+	}
+}